@@ -15,6 +15,56 @@ import (
 func init() {
 	rootCmd.AddCommand(datarepoCmd)
 	datarepoCmd.AddCommand(ingestCmd)
+
+	ingestCmd.Flags().StringVar(&ingestManifestPath, "manifest", "", "Path to a pre-authored ingest manifest, skipping the interactive datasource prompts")
+	ingestCmd.Flags().BoolVar(&ingestManifestJSON, "json", false, "Expect --manifest to be JSON rather than YAML; both are read by the same decoder, so this only changes the format named in parse errors")
+	ingestCmd.Flags().BoolVar(&ingestYes, "yes", false, "Skip confirmation and editor prompts; accept the detected schema as-is")
+
+	ingestCmd.Flags().IntVar(&ingestSampleURIs, "sample-uris", defaultIngestSampleURIs, "How many s3:// / http(s):// URI values per column to follow and content-sniff when refining the detected schema")
+	ingestCmd.Flags().BoolVar(&ingestNoSampleURIs, "no-sample-uris", false, "Disable URI content sniffing entirely, overriding --sample-uris")
+
+	ingestCmd.Flags().StringVar(&ingestLocationID, "location", "", "Datasource location backend to use (e.g. aws_s3, gcs, azure_blob), skipping the location prompt")
+	ingestCmd.Flags().StringArrayVar(&ingestLocationArgs, "location-arg", nil, "A positional argument for --location (e.g. --location-arg=my-bucket --location-arg=my-prefix). Repeat in order; see the backend's FromArgs for the expected arguments")
+	cobra.CheckErr(ingestCmd.RegisterFlagCompletionFunc("location", completeIngestLocationFlag))
+	cobra.CheckErr(ingestCmd.RegisterFlagCompletionFunc("location-arg", completeIngestLocationArgFlag))
+}
+
+var (
+	ingestManifestPath string
+	ingestManifestJSON bool
+	ingestYes          bool
+
+	ingestSampleURIs   int
+	ingestNoSampleURIs bool
+
+	ingestLocationID   string
+	ingestLocationArgs []string
+)
+
+// defaultIngestSampleURIs is the --sample-uris default: enough values per column to
+// give URI sniffing a confident answer without making schema detection noticeably
+// slower for users who never asked for it.
+const defaultIngestSampleURIs = 5
+
+// completeIngestLocationFlag completes --location with the IDs of registered
+// ingest.LocationBackendFactories.
+func completeIngestLocationFlag(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	var completions []string
+	for _, factory := range ingest.LocationBackendFactories() {
+		completions = append(completions, factory.ID)
+	}
+	return completions, cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeIngestLocationArgFlag completes the next --location-arg using the selected
+// --location backend's CompleteArg, based on how many --location-arg values have
+// already been supplied.
+func completeIngestLocationArgFlag(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	factory, ok := ingest.LookupLocationBackend(ingestLocationID)
+	if !ok || factory.CompleteArg == nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	return factory.CompleteArg(len(ingestLocationArgs), ingestLocationArgs, toComplete), cobra.ShellCompDirectiveNoFileComp
 }
 
 var SchemaEditorTutorialBlurb = `# This editor lets you make manual modifications to your field types to help Daft ingest your data.
@@ -45,6 +95,11 @@ var (
 		Value:       ingest.DataformatIDDatabaseTable,
 		Description: "A database table from databases such as PostgreSQL, Snowflake or BigQuery.",
 	}
+	SQLSelectSelector = selectPromptData{
+		Name:        "SQL Select (S3 Select)",
+		Value:       ingest.DataformatIDSQLSelect,
+		Description: "A SQL expression pushed down to S3 Select, ingesting only the projected/filtered subset of records.",
+	}
 
 	LocalDirectorySelector = selectPromptData{
 		Name:        "Local Directory (WIP)",
@@ -56,6 +111,16 @@ var (
 		Value:       ingest.DatasourceIDAWSS3,
 		Description: "An AWS S3 Bucket and prefix, indicating a collection of AWS S3 objects.",
 	}
+	GCSSelector = selectPromptData{
+		Name:        "Google Cloud Storage",
+		Value:       ingest.DatasourceIDGCS,
+		Description: "A GCS bucket and prefix (gs://bucket/prefix), indicating a collection of GCS objects.",
+	}
+	AzureBlobSelector = selectPromptData{
+		Name:        "Azure Blob Storage",
+		Value:       ingest.DatasourceIDAzureBlob,
+		Description: "An Azure Blob Storage account, container and prefix.",
+	}
 
 	CommasSelector = selectPromptData{
 		Name:        "Commas: ,",
@@ -67,15 +132,83 @@ var (
 		Value:       ingest.CSVDelimiterTabs,
 		Description: "Values in each column are separated by a tab.",
 	}
+
+	CompressionAutoSelector = selectPromptData{
+		Name:        "Auto-detect",
+		Value:       ingest.CompressionAuto,
+		Description: "Detect compression per-file from its extension (e.g. .csv.gz, .csv.zst).",
+	}
+	CompressionNoneSelector = selectPromptData{
+		Name:        "None",
+		Value:       ingest.CompressionNone,
+		Description: "Files are plain, uncompressed CSV.",
+	}
+	CompressionGzipSelector = selectPromptData{
+		Name:        "gzip",
+		Value:       ingest.CompressionGzip,
+		Description: "Files are gzip-compressed (.gz).",
+	}
+	CompressionZstdSelector = selectPromptData{
+		Name:        "zstd",
+		Value:       ingest.CompressionZstd,
+		Description: "Files are zstd-compressed (.zst).",
+	}
+	CompressionSnappySelector = selectPromptData{
+		Name:        "snappy",
+		Value:       ingest.CompressionSnappy,
+		Description: "Files are snappy-compressed (.sz).",
+	}
+
+	SerializationCSVSelector = selectPromptData{
+		Name:        "CSV",
+		Value:       ingest.SerializationCSV,
+		Description: "Comma-separated values.",
+	}
+	SerializationJSONSelector = selectPromptData{
+		Name:        "JSON",
+		Value:       ingest.SerializationJSON,
+		Description: "Newline-delimited JSON records.",
+	}
+	SerializationParquetSelector = selectPromptData{
+		Name:        "Parquet",
+		Value:       ingest.SerializationParquet,
+		Description: "Columnar Parquet files.",
+	}
 )
 
-var locationSelectors = []selectPromptData{
-	AWSS3Selector,
-	LocalDirectorySelector,
+// locationSelectors lists the datasource locations offered by the interactive
+// prompt. Backends registered via ingest.RegisterLocationBackend (AWS S3, GCS, Azure
+// Blob) are picked up automatically; LocalDirectory is listed alongside them as a WIP
+// option that isn't backed by the registry yet.
+var locationSelectors = buildLocationSelectors()
+
+func buildLocationSelectors() []selectPromptData {
+	selectors := make([]selectPromptData, 0, len(ingest.LocationBackendFactories())+1)
+	for _, factory := range ingest.LocationBackendFactories() {
+		selectors = append(selectors, selectPromptData{
+			Name:        factory.Name,
+			Value:       factory.ID,
+			Description: factory.Description,
+		})
+	}
+	return append(selectors, LocalDirectorySelector)
+}
+
+// locationPromptBuilders maps a datasource location ID to the interactive prompt flow
+// that builds its ingest.ManifestConfig. Kept in the cmd package (rather than the
+// registry) since prompting is a CLI concern the ingest package doesn't know about.
+var locationPromptBuilders = map[string]func() (ingest.ManifestConfig, error){
+	AWSS3Selector.Value: func() (ingest.ManifestConfig, error) { return NewAWSS3LocationConfigFromPrompts() },
+	GCSSelector.Value:   func() (ingest.ManifestConfig, error) { return NewGCSLocationConfigFromPrompts() },
+	AzureBlobSelector.Value: func() (ingest.ManifestConfig, error) {
+		return NewAzureBlobLocationConfigFromPrompts()
+	},
 }
 
 var allowedSelectors = map[string][]selectPromptData{
-	AWSS3Selector.Value:          {CommaSeparatedValuesFilesSelector, IndividualBinaryFilesSelector},
+	AWSS3Selector.Value:          {CommaSeparatedValuesFilesSelector, IndividualBinaryFilesSelector, SQLSelectSelector},
+	GCSSelector.Value:            {CommaSeparatedValuesFilesSelector, IndividualBinaryFilesSelector},
+	AzureBlobSelector.Value:      {CommaSeparatedValuesFilesSelector, IndividualBinaryFilesSelector},
 	LocalDirectorySelector.Value: {CommaSeparatedValuesFilesSelector, IndividualBinaryFilesSelector},
 }
 
@@ -84,12 +217,46 @@ var csvDelimiterSelectors = []selectPromptData{
 	TabsSelector,
 }
 
+var compressionSelectors = []selectPromptData{
+	CompressionAutoSelector,
+	CompressionNoneSelector,
+	CompressionGzipSelector,
+	CompressionZstdSelector,
+	CompressionSnappySelector,
+}
+
+var sqlSelectInputSerializationSelectors = []selectPromptData{
+	SerializationCSVSelector,
+	SerializationJSONSelector,
+	SerializationParquetSelector,
+}
+
+// sqlSelectOutputSerializationSelectors is narrower than the input selectors because
+// S3 Select can only return matched records as CSV or JSON, never Parquet.
+var sqlSelectOutputSerializationSelectors = []selectPromptData{
+	SerializationCSVSelector,
+	SerializationJSONSelector,
+}
+
+// sqlSelectCompressionSelectors is narrower than compressionSelectors because S3
+// Select only supports gzip compression on its input files, never zstd or snappy.
+var sqlSelectCompressionSelectors = []selectPromptData{
+	CompressionAutoSelector,
+	CompressionNoneSelector,
+	CompressionGzipSelector,
+}
+
 type IngestManifest struct {
 	selectedDatasourceType selectPromptData
 	DatasourceFormatConfig ingest.ManifestConfig `yaml:"datasourceType"`
 
 	selectedDatasourceLocation selectPromptData
 	DatasourceLocationConfig   ingest.ManifestConfig `yaml:"datasourceLocation"`
+
+	// Files lists every object under DatasourceLocationConfig, resolved to a
+	// splittability flag via resolveFiles so downstream ingest workers know which
+	// files must be read as a single indivisible region.
+	Files []ingest.FileEntry `yaml:"files"`
 }
 
 func NewCSVFilesFormatConfigFromPrompts() (*ingest.CSVFilesFormatConfig, error) {
@@ -108,6 +275,56 @@ func NewCSVFilesFormatConfigFromPrompts() (*ingest.CSVFilesFormatConfig, error)
 		return nil, err
 	}
 	config.Header = headerResult
+	compressionResult, err := SelectPrompt(
+		"Compression",
+		"How are your CSV files compressed? Compressed files are ingested as a single indivisible region per file.",
+		compressionSelectors,
+	)
+	if err != nil {
+		return nil, err
+	}
+	config.Compression = compressionResult.Value
+	return &config, nil
+}
+
+func NewSQLSelectFormatConfigFromPrompts() (*ingest.SQLSelectFormatConfig, error) {
+	config := ingest.SQLSelectFormatConfig{}
+	expression, err := TextPrompt("SQL Expression (e.g. SELECT s.id, s.ts FROM S3Object s WHERE s.region='us')")
+	if err != nil {
+		return nil, err
+	}
+	config.Expression = expression
+
+	inputResult, err := SelectPrompt(
+		"Input Serialization",
+		"The format of the underlying files the SQL expression is run against.",
+		sqlSelectInputSerializationSelectors,
+	)
+	if err != nil {
+		return nil, err
+	}
+	config.InputSerialization = inputResult.Value
+
+	outputResult, err := SelectPrompt(
+		"Output Serialization",
+		"The format S3 Select should return matched records in.",
+		sqlSelectOutputSerializationSelectors,
+	)
+	if err != nil {
+		return nil, err
+	}
+	config.OutputSerialization = outputResult.Value
+
+	compressionResult, err := SelectPrompt(
+		"Compression",
+		"How are the underlying files compressed? S3 Select only supports gzip.",
+		sqlSelectCompressionSelectors,
+	)
+	if err != nil {
+		return nil, err
+	}
+	config.Compression = compressionResult.Value
+
 	return &config, nil
 }
 
@@ -130,6 +347,79 @@ func NewAWSS3LocationConfigFromPrompts() (*ingest.AWSS3LocationConfig, error) {
 	return &config, nil
 }
 
+func NewGCSLocationConfigFromPrompts() (*ingest.GCSLocationConfig, error) {
+	config := ingest.GCSLocationConfig{}
+	{
+		result, err := TextPrompt("GCS Bucket")
+		if err != nil {
+			return nil, err
+		}
+		config.Bucket = result
+	}
+	{
+		result, err := TextPrompt("GCS Prefix")
+		if err != nil {
+			return nil, err
+		}
+		config.Prefix = result
+	}
+	useADC, err := BoolPrompt("Authenticate using Application Default Credentials")
+	if err != nil {
+		return nil, err
+	}
+	if !useADC {
+		result, err := TextPrompt("Path to GCS service account credentials file")
+		if err != nil {
+			return nil, err
+		}
+		config.CredentialsFile = result
+	}
+	return &config, nil
+}
+
+func NewAzureBlobLocationConfigFromPrompts() (*ingest.AzureBlobLocationConfig, error) {
+	config := ingest.AzureBlobLocationConfig{}
+	{
+		result, err := TextPrompt("Azure Storage Account")
+		if err != nil {
+			return nil, err
+		}
+		config.Account = result
+	}
+	{
+		result, err := TextPrompt("Azure Blob Container")
+		if err != nil {
+			return nil, err
+		}
+		config.Container = result
+	}
+	{
+		result, err := TextPrompt("Azure Blob Prefix")
+		if err != nil {
+			return nil, err
+		}
+		config.Prefix = result
+	}
+	useConnectionString, err := BoolPrompt("Authenticate using a connection string (instead of a SAS token)")
+	if err != nil {
+		return nil, err
+	}
+	if useConnectionString {
+		result, err := TextPrompt("Azure Storage Connection String")
+		if err != nil {
+			return nil, err
+		}
+		config.ConnectionString = result
+	} else {
+		result, err := TextPrompt("Azure Blob SAS Token")
+		if err != nil {
+			return nil, err
+		}
+		config.SASToken = result
+	}
+	return &config, nil
+}
+
 // Builds the configuration for the DatasourceType
 func (manifest *IngestManifest) buildDatasourceFormatConfig() error {
 	selectors := allowedSelectors[manifest.selectedDatasourceLocation.Value]
@@ -155,13 +445,37 @@ func (manifest *IngestManifest) buildDatasourceFormatConfig() error {
 		return nil
 	case DatabaseTableSelector.Value:
 		return errors.New("database tables not yet supported")
+	case SQLSelectSelector.Value:
+		config, err := NewSQLSelectFormatConfigFromPrompts()
+		if err != nil {
+			return err
+		}
+		manifest.selectedDatasourceType = result
+		manifest.DatasourceFormatConfig = config
+		return nil
 	default:
 		return fmt.Errorf("datasource type %s not supported", result)
 	}
 }
 
-// Builds the configuration for the DatasourceLocation
+// Builds the configuration for the DatasourceLocation. If --location was passed, the
+// backend and its positional --location-arg values are used directly, skipping the
+// interactive prompt entirely.
 func (manifest *IngestManifest) buildDatasourceLocationConfig() error {
+	if ingestLocationID != "" {
+		factory, ok := ingest.LookupLocationBackend(ingestLocationID)
+		if !ok {
+			return fmt.Errorf("datasource location %s not supported", ingestLocationID)
+		}
+		config, err := factory.FromArgs(ingestLocationArgs)
+		if err != nil {
+			return err
+		}
+		manifest.selectedDatasourceLocation = selectPromptData{Name: factory.Name, Value: factory.ID, Description: factory.Description}
+		manifest.DatasourceLocationConfig = config
+		return nil
+	}
+
 	result, err := SelectPrompt(
 		"Data Source",
 		"Specify the source for importing data from.",
@@ -180,18 +494,31 @@ func (manifest *IngestManifest) buildDatasourceLocationConfig() error {
 }
 
 func buildDatasourceLocationConfigForSelectedLocation(location selectPromptData) (ingest.ManifestConfig, error) {
-	switch location {
-	case LocalDirectorySelector:
+	if location.Value == LocalDirectorySelector.Value {
 		return nil, errors.New("local directories not yet supported")
-	case AWSS3Selector:
-		config, err := NewAWSS3LocationConfigFromPrompts()
-		if err != nil {
-			return nil, err
-		}
-		return config, nil
-	default:
-		return nil, fmt.Errorf("datasource location %s not supported", location)
 	}
+	builder, ok := locationPromptBuilders[location.Value]
+	if !ok {
+		return nil, fmt.Errorf("datasource location %s not supported", location.Value)
+	}
+	return builder()
+}
+
+// resolveFiles lists the configured location's objects and resolves each into an
+// ingest.FileEntry, recording compression/splittability for downstream ingest
+// workers. Only location configs backed by an ingest.LocationBackend support
+// listing; local directories are WIP.
+func (manifest *IngestManifest) resolveFiles() error {
+	location, ok := manifest.DatasourceLocationConfig.(ingest.LocationBackend)
+	if !ok {
+		return fmt.Errorf("cannot list files for datasource location %T", manifest.DatasourceLocationConfig)
+	}
+	files, err := ingest.ResolveFiles(location, manifest.DatasourceFormatConfig)
+	if err != nil {
+		return err
+	}
+	manifest.Files = files
+	return nil
 }
 
 func (manifest *IngestManifest) confirmDatasourceConfigs() error {
@@ -212,16 +539,20 @@ func (manifest *IngestManifest) confirmDatasourceConfigs() error {
 	return nil
 }
 
-func (manifest *IngestManifest) buildDatarepoSchema() error {
-	sampler, err := sample.SamplerFactory(manifest.DatasourceFormatConfig, manifest.DatasourceLocationConfig)
-	if err != nil {
-		return err
+// buildDatarepoSchema samples the configured datasource to detect a schema and lets
+// the user refine it in their editor before confirming. When skipPrompts is set (the
+// CLI's --yes flag), the detected schema is accepted as-is without opening an editor
+// or asking for confirmation, so the command can run unattended in CI or scripts.
+func (manifest *IngestManifest) buildDatarepoSchema(skipPrompts bool, sampleURIs int) error {
+	var sampleKey string
+	if len(manifest.Files) > 0 {
+		sampleKey = manifest.Files[0].URI
 	}
-	sampledSchema, err := sampler.SampleSchema()
+	sampler, err := sample.SamplerFactory(manifest.DatasourceFormatConfig, manifest.DatasourceLocationConfig, sampleURIs, sampleKey)
 	if err != nil {
 		return err
 	}
-	tablePreview, err := PreviewSamples(sampledSchema, sampler)
+	sampledSchema, err := sampler.SampleSchema()
 	if err != nil {
 		return err
 	}
@@ -230,10 +561,19 @@ func (manifest *IngestManifest) buildDatarepoSchema() error {
 	if err != nil {
 		return err
 	}
-	finalizedSchemaStr, err := EditorPrompt(SchemaEditorTutorialBlurb+tablePreview+string(yamlSchema), "yaml")
-	if err != nil {
-		return err
+
+	finalizedSchemaStr := string(yamlSchema)
+	if !skipPrompts {
+		tablePreview, err := PreviewSamples(sampledSchema, sampler)
+		if err != nil {
+			return err
+		}
+		finalizedSchemaStr, err = EditorPrompt(SchemaEditorTutorialBlurb+tablePreview+string(yamlSchema), "yaml")
+		if err != nil {
+			return err
+		}
 	}
+
 	recordField := schema.SchemaField{}
 	err = yaml.Unmarshal([]byte(finalizedSchemaStr), &recordField)
 	if err != nil {
@@ -242,12 +582,14 @@ func (manifest *IngestManifest) buildDatarepoSchema() error {
 
 	fmt.Println("Final Schema:")
 	fmt.Println(finalizedSchemaStr)
-	confirmSchema, err := BoolPrompt("Confirm finalized schema")
-	if err != nil {
-		return err
-	}
-	if !confirmSchema {
-		return errors.New("aborted finalizing schema")
+	if !skipPrompts {
+		confirmSchema, err := BoolPrompt("Confirm finalized schema")
+		if err != nil {
+			return err
+		}
+		if !confirmSchema {
+			return errors.New("aborted finalizing schema")
+		}
 	}
 
 	return nil
@@ -268,21 +610,46 @@ var ingestCmd = &cobra.Command{
 	Long: `
 Interactive UI for ingesting data from an existing data source, creating a new Data Repo.
 Daft does a best-effort detection and generation of a schema, but users will be able to
-modify and confirm the schema manually before creating the repo and ingesting data.`,
+modify and confirm the schema manually before creating the repo and ingesting data.
+
+Pass --manifest with a path to a pre-authored ingest manifest (see the output of a
+previous interactive run) to skip the datasource prompts, and --yes to additionally
+skip schema confirmation, making the command suitable for CI and scripts.`,
 	Run: func(cmd *cobra.Command, args []string) {
 		fmt.Println("")
-		var manifest IngestManifest
-
-		err := manifest.buildDatasourceLocationConfig()
-		cobra.CheckErr(err)
+		var manifest *IngestManifest
+
+		if ingestManifestPath != "" {
+			loaded, err := LoadIngestManifest(ingestManifestPath, ingestManifestJSON)
+			cobra.CheckErr(err)
+			manifest = loaded
+		} else {
+			manifest = &IngestManifest{}
+			err := manifest.buildDatasourceLocationConfig()
+			cobra.CheckErr(err)
+
+			err = manifest.buildDatasourceFormatConfig()
+			cobra.CheckErr(err)
+		}
 
-		err = manifest.buildDatasourceFormatConfig()
-		cobra.CheckErr(err)
+		if !ingestYes {
+			err := manifest.confirmDatasourceConfigs()
+			cobra.CheckErr(err)
+		}
 
-		err = manifest.confirmDatasourceConfigs()
-		cobra.CheckErr(err)
+		// Listing the location's objects happens only after the user has had a chance
+		// to back out via confirmDatasourceConfigs, so a mistaken bucket/prefix answer
+		// is cheap to correct rather than paying for a full listing first.
+		if len(manifest.Files) == 0 {
+			err := manifest.resolveFiles()
+			cobra.CheckErr(err)
+		}
 
-		err = manifest.buildDatarepoSchema()
+		sampleURIs := ingestSampleURIs
+		if ingestNoSampleURIs {
+			sampleURIs = 0
+		}
+		err := manifest.buildDatarepoSchema(ingestYes, sampleURIs)
 		cobra.CheckErr(err)
 	},
 }
@@ -0,0 +1,189 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/Eventual-Inc/Daft/pkg/datarepo/ingest"
+)
+
+// manifestYAML is the on-disk shape of an IngestManifest: each side carries an
+// explicit "kind" discriminator (a DatasourceID/DataformatID) alongside its config
+// fields, so a manifest loaded from disk can be decoded into the right concrete
+// ingest.ManifestConfig without relying on field order or external context.
+type manifestYAML struct {
+	DatasourceType     yaml.Node          `yaml:"datasourceType"`
+	DatasourceLocation yaml.Node          `yaml:"datasourceLocation"`
+	Files              []ingest.FileEntry `yaml:"files"`
+}
+
+// MarshalYAML renders the manifest with a "kind" discriminator alongside each
+// config's own fields, matching the shape LoadIngestManifest expects to read back.
+func (manifest IngestManifest) MarshalYAML() (interface{}, error) {
+	locationYAML, err := configWithKind(manifest.DatasourceLocationConfig)
+	if err != nil {
+		return nil, err
+	}
+	typeYAML, err := configWithKind(manifest.DatasourceFormatConfig)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{
+		"datasourceLocation": locationYAML,
+		"datasourceType":     typeYAML,
+		"files":              manifest.Files,
+	}, nil
+}
+
+func configWithKind(config ingest.ManifestConfig) (map[string]interface{}, error) {
+	raw, err := yaml.Marshal(config)
+	if err != nil {
+		return nil, err
+	}
+	var fields map[string]interface{}
+	if err := yaml.Unmarshal(raw, &fields); err != nil {
+		return nil, err
+	}
+	if fields == nil {
+		fields = map[string]interface{}{}
+	}
+	fields["kind"] = config.Kind()
+	return fields, nil
+}
+
+// UnmarshalYAML decodes a manifest written by MarshalYAML (or hand-authored in the
+// same shape), validating the chosen datasource type against the same
+// allowedSelectors matrix used by the interactive prompts.
+func (manifest *IngestManifest) UnmarshalYAML(node *yaml.Node) error {
+	var raw manifestYAML
+	if err := node.Decode(&raw); err != nil {
+		return err
+	}
+
+	locationSelector, locationConfig, err := decodeLocationConfig(&raw.DatasourceLocation)
+	if err != nil {
+		return fmt.Errorf("decoding datasourceLocation: %w", err)
+	}
+	formatSelector, formatConfig, err := decodeFormatConfig(&raw.DatasourceType)
+	if err != nil {
+		return fmt.Errorf("decoding datasourceType: %w", err)
+	}
+
+	allowed := allowedSelectors[locationSelector.Value]
+	if !containsSelector(allowed, formatSelector) {
+		return fmt.Errorf("datasource type %q is not supported for datasource location %q", formatSelector.Value, locationSelector.Value)
+	}
+
+	manifest.selectedDatasourceLocation = locationSelector
+	manifest.DatasourceLocationConfig = locationConfig
+	manifest.selectedDatasourceType = formatSelector
+	manifest.DatasourceFormatConfig = formatConfig
+	manifest.Files = raw.Files
+	return nil
+}
+
+func containsSelector(selectors []selectPromptData, target selectPromptData) bool {
+	for _, s := range selectors {
+		if s.Value == target.Value {
+			return true
+		}
+	}
+	return false
+}
+
+// decodeLocationConfig decodes a datasourceLocation node by looking its "kind" up in
+// the ingest.LocationBackend registry, so manifests referencing GCS, Azure Blob or any
+// future registered backend decode without this function needing to know about them.
+func decodeLocationConfig(node *yaml.Node) (selectPromptData, ingest.ManifestConfig, error) {
+	kind, err := decodeKind(node)
+	if err != nil {
+		return selectPromptData{}, nil, err
+	}
+	if kind == LocalDirectorySelector.Value {
+		return selectPromptData{}, nil, fmt.Errorf("datasource location %q not yet supported", kind)
+	}
+	factory, ok := ingest.LookupLocationBackend(kind)
+	if !ok {
+		return selectPromptData{}, nil, fmt.Errorf("unrecognized datasource location kind %q", kind)
+	}
+	config := factory.New()
+	if err := node.Decode(config); err != nil {
+		return selectPromptData{}, nil, err
+	}
+	selector := selectPromptData{Name: factory.Name, Value: factory.ID, Description: factory.Description}
+	return selector, config, nil
+}
+
+func decodeFormatConfig(node *yaml.Node) (selectPromptData, ingest.ManifestConfig, error) {
+	kind, err := decodeKind(node)
+	if err != nil {
+		return selectPromptData{}, nil, err
+	}
+	switch kind {
+	case CommaSeparatedValuesFilesSelector.Value:
+		config := &ingest.CSVFilesFormatConfig{}
+		if err := node.Decode(config); err != nil {
+			return selectPromptData{}, nil, err
+		}
+		if config.Delimiter == "" {
+			return selectPromptData{}, nil, fmt.Errorf("missing required %q field", "delimiter")
+		}
+		return CommaSeparatedValuesFilesSelector, config, nil
+	case SQLSelectSelector.Value:
+		config := &ingest.SQLSelectFormatConfig{}
+		if err := node.Decode(config); err != nil {
+			return selectPromptData{}, nil, err
+		}
+		if config.Expression == "" {
+			return selectPromptData{}, nil, fmt.Errorf("missing required %q field", "expression")
+		}
+		if config.InputSerialization == "" {
+			return selectPromptData{}, nil, fmt.Errorf("missing required %q field", "inputSerialization")
+		}
+		if config.OutputSerialization == "" {
+			return selectPromptData{}, nil, fmt.Errorf("missing required %q field", "outputSerialization")
+		}
+		if !ingest.ValidSQLSelectCompression(config.Compression) {
+			return selectPromptData{}, nil, fmt.Errorf("S3 Select does not support %s compression", config.Compression)
+		}
+		return SQLSelectSelector, config, nil
+	case IndividualBinaryFilesSelector.Value, DatabaseTableSelector.Value:
+		return selectPromptData{}, nil, fmt.Errorf("datasource type %q not yet supported", kind)
+	default:
+		return selectPromptData{}, nil, fmt.Errorf("unrecognized datasource type kind %q", kind)
+	}
+}
+
+func decodeKind(node *yaml.Node) (string, error) {
+	var withKind struct {
+		Kind string `yaml:"kind"`
+	}
+	if err := node.Decode(&withKind); err != nil {
+		return "", err
+	}
+	if withKind.Kind == "" {
+		return "", fmt.Errorf("missing required \"kind\" field")
+	}
+	return withKind.Kind, nil
+}
+
+// LoadIngestManifest reads a pre-authored IngestManifest from path. Manifests are
+// written as YAML; since JSON is a subset of YAML's flow syntax the same decoder
+// reads both, so asJSON only affects error messaging around the expected format.
+func LoadIngestManifest(path string, asJSON bool) (*IngestManifest, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading manifest %s: %w", path, err)
+	}
+	var manifest IngestManifest
+	if err := yaml.Unmarshal(data, &manifest); err != nil {
+		format := "YAML"
+		if asJSON {
+			format = "JSON"
+		}
+		return nil, fmt.Errorf("parsing manifest %s as %s: %w", path, format, err)
+	}
+	return &manifest, nil
+}
@@ -0,0 +1,167 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func mustDecodeNode(t *testing.T, y string) *yaml.Node {
+	t.Helper()
+	var doc yaml.Node
+	if err := yaml.Unmarshal([]byte(y), &doc); err != nil {
+		t.Fatalf("parsing test yaml: %v", err)
+	}
+	if len(doc.Content) != 1 {
+		t.Fatalf("expected a single document, got %d", len(doc.Content))
+	}
+	return doc.Content[0]
+}
+
+func TestDecodeFormatConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		yaml    string
+		wantErr string
+	}{
+		{
+			name: "valid csv files",
+			yaml: `
+kind: csv_files
+delimiter: ","
+header: true
+compression: auto
+`,
+		},
+		{
+			name: "csv files missing delimiter",
+			yaml: `
+kind: csv_files
+header: true
+compression: auto
+`,
+			wantErr: `missing required "delimiter" field`,
+		},
+		{
+			name: "valid sql select",
+			yaml: `
+kind: sql_select
+expression: "SELECT * FROM S3Object"
+inputSerialization: csv
+outputSerialization: json
+compression: none
+`,
+		},
+		{
+			name: "sql select missing expression",
+			yaml: `
+kind: sql_select
+inputSerialization: csv
+outputSerialization: json
+`,
+			wantErr: `missing required "expression" field`,
+		},
+		{
+			name: "sql select missing output serialization",
+			yaml: `
+kind: sql_select
+expression: "SELECT * FROM S3Object"
+inputSerialization: csv
+`,
+			wantErr: `missing required "outputSerialization" field`,
+		},
+		{
+			name: "sql select unsupported compression",
+			yaml: `
+kind: sql_select
+expression: "SELECT * FROM S3Object"
+inputSerialization: csv
+outputSerialization: json
+compression: zstd
+`,
+			wantErr: "S3 Select does not support zstd compression",
+		},
+		{
+			name: "unrecognized kind",
+			yaml: `
+kind: xml_files
+`,
+			wantErr: `unrecognized datasource type kind`,
+		},
+		{
+			name: "missing kind",
+			yaml: `
+delimiter: ","
+`,
+			wantErr: `missing required "kind" field`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			node := mustDecodeNode(t, tt.yaml)
+			_, _, err := decodeFormatConfig(node)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("decodeFormatConfig() unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("decodeFormatConfig() error = %v, want containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestDecodeLocationConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		yaml    string
+		wantErr string
+	}{
+		{
+			name: "valid aws s3",
+			yaml: `
+kind: aws_s3
+bucket: my-bucket
+prefix: my-prefix
+`,
+		},
+		{
+			name: "local directory not yet supported",
+			yaml: `
+kind: local_directory
+`,
+			wantErr: "not yet supported",
+		},
+		{
+			name: "unrecognized kind",
+			yaml: `
+kind: ftp
+`,
+			wantErr: "unrecognized datasource location kind",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			node := mustDecodeNode(t, tt.yaml)
+			selector, config, err := decodeLocationConfig(node)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("decodeLocationConfig() unexpected error: %v", err)
+				}
+				if config == nil {
+					t.Fatalf("decodeLocationConfig() returned nil config")
+				}
+				if selector.Value != AWSS3Selector.Value {
+					t.Fatalf("decodeLocationConfig() selector = %+v, want %+v", selector, AWSS3Selector)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("decodeLocationConfig() error = %v, want containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
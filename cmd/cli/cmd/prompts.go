@@ -0,0 +1,121 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/AlecAivazis/survey/v2"
+
+	"github.com/Eventual-Inc/Daft/pkg/datarepo/ingest/sample"
+	"github.com/Eventual-Inc/Daft/pkg/datarepo/schema"
+)
+
+// selectPromptData is a single option offered to the user by SelectPrompt. Value
+// carries whatever underlying ID the option represents (a DataformatID, DatasourceID,
+// CSVDelimiter, ...) — all of which are string aliases, so callers can switch on Value
+// directly once a choice has been made.
+type selectPromptData struct {
+	Name        string
+	Value       string
+	Description string
+}
+
+func (d selectPromptData) String() string {
+	return d.Name
+}
+
+// SelectPrompt asks the user to pick one of options, showing message as the prompt
+// and help as additional context.
+func SelectPrompt(message, help string, options []selectPromptData) (selectPromptData, error) {
+	names := make([]string, len(options))
+	for i, opt := range options {
+		names[i] = opt.Name
+	}
+	var choice string
+	prompt := &survey.Select{
+		Message: message,
+		Options: names,
+		Help:    help,
+	}
+	if err := survey.AskOne(prompt, &choice); err != nil {
+		return selectPromptData{}, err
+	}
+	for _, opt := range options {
+		if opt.Name == choice {
+			return opt, nil
+		}
+	}
+	return selectPromptData{}, fmt.Errorf("unrecognized selection: %s", choice)
+}
+
+// TextPrompt asks the user to type a free-form string response to message.
+func TextPrompt(message string) (string, error) {
+	var result string
+	prompt := &survey.Input{Message: message}
+	if err := survey.AskOne(prompt, &result); err != nil {
+		return "", err
+	}
+	return result, nil
+}
+
+// BoolPrompt asks the user a yes/no question.
+func BoolPrompt(message string) (bool, error) {
+	var result bool
+	prompt := &survey.Confirm{Message: message}
+	if err := survey.AskOne(prompt, &result); err != nil {
+		return false, err
+	}
+	return result, nil
+}
+
+// EditorPrompt opens the user's $EDITOR pre-populated with initialValue (e.g. a YAML
+// schema to review and modify) and returns the saved contents.
+func EditorPrompt(initialValue, fileExtension string) (string, error) {
+	var result string
+	prompt := &survey.Editor{
+		Message:       "Press <enter> to open your editor",
+		Default:       initialValue,
+		HideDefault:   true,
+		AppendDefault: true,
+		FileName:      "*." + fileExtension,
+	}
+	if err := survey.AskOne(prompt, &result); err != nil {
+		return "", err
+	}
+	return result, nil
+}
+
+// PreviewSamples renders a small table of sampled rows for sampledSchema, commented
+// out as YAML so it can be prepended to the schema shown in the editor for context.
+func PreviewSamples(sampledSchema *schema.SchemaField, sampler sample.Sampler) (string, error) {
+	rows, err := sampler.Preview(5)
+	if err != nil {
+		return "", err
+	}
+
+	var b strings.Builder
+	b.WriteString("# Preview of sampled data:\n")
+	for _, row := range rows {
+		b.WriteString("#   ")
+		b.WriteString(strings.Join(row, ", "))
+		b.WriteString("\n")
+	}
+
+	if stats := sampler.SniffStats(); len(stats) > 0 {
+		b.WriteString("#\n")
+		b.WriteString("# URI sniffing:\n")
+		for _, stat := range stats {
+			b.WriteString(fmt.Sprintf("#   %s: %d/%d URIs sniffed", stat.Column, stat.SniffedCount, stat.URICount))
+			if stat.ContentType != "" {
+				b.WriteString(fmt.Sprintf(", refined to %s", stat.ContentType))
+			} else if stat.SniffedCount == 0 {
+				b.WriteString(", no URIs could be sniffed, type left unrefined")
+			} else {
+				b.WriteString(", content type did not agree across samples, type left unrefined")
+			}
+			b.WriteString("\n")
+		}
+	}
+	b.WriteString("\n\n")
+	return b.String(), nil
+}
@@ -0,0 +1,121 @@
+// Package ingest defines the configuration types used to describe how a Data Repo
+// should be created from an existing datasource: what format the data is laid out in
+// (CSV files, individual files, a database table, ...) and where it lives (AWS S3,
+// local disk, ...).
+package ingest
+
+import "strings"
+
+// DataformatID identifies the layout of the data being ingested. It is an alias for
+// string (rather than a distinct type) so that it interchanges freely with
+// selectPromptData.Value and with map keys in the cmd package's selector tables.
+type DataformatID = string
+
+const (
+	DataformatIDIndividualFiles DataformatID = "individual_files"
+	DataformatIDCSVFiles        DataformatID = "csv_files"
+	DataformatIDDatabaseTable   DataformatID = "database_table"
+	DataformatIDSQLSelect       DataformatID = "sql_select"
+)
+
+// DatasourceID identifies where the data being ingested lives.
+type DatasourceID = string
+
+const (
+	DatasourceIDLocalDirectory DatasourceID = "local_directory"
+	DatasourceIDAWSS3          DatasourceID = "aws_s3"
+	DatasourceIDGCS            DatasourceID = "gcs"
+	DatasourceIDAzureBlob      DatasourceID = "azure_blob"
+)
+
+// CSVDelimiter is the character used to separate columns in a CSV file.
+type CSVDelimiter = string
+
+const (
+	CSVDelimiterCommas CSVDelimiter = ","
+	CSVDelimiterTabs   CSVDelimiter = "\t"
+)
+
+// ManifestConfig is implemented by every datasource format and location configuration
+// that can be serialized into an IngestManifest YAML file and handed to
+// sample.SamplerFactory to build a Sampler.
+type ManifestConfig interface {
+	// Kind returns the Dataformat/Datasource ID that this config was built for, so a
+	// manifest loaded back from disk can be decoded into the right Go type.
+	Kind() string
+}
+
+// Compression identifies how a datasource's underlying files are compressed on disk.
+// CompressionAuto detects compression per-file from its extension (e.g. ".csv.gz");
+// it is the default so that users ingesting a mix of compressed and plain files don't
+// need to split them into separate ingest runs. Unlike plain files, compressed files
+// cannot be split into byte-range regions, so the resolved compression is recorded
+// alongside each file in the manifest and downstream ingest workers read such files
+// as a single indivisible region.
+type Compression = string
+
+const (
+	CompressionAuto   Compression = "auto"
+	CompressionNone   Compression = "none"
+	CompressionGzip   Compression = "gzip"
+	CompressionZstd   Compression = "zstd"
+	CompressionSnappy Compression = "snappy"
+)
+
+// DetectCompression returns the Compression implied by a file name's extension, or
+// CompressionNone if the name doesn't carry a recognized compressed extension.
+func DetectCompression(fileName string) Compression {
+	switch {
+	case strings.HasSuffix(fileName, ".gz"):
+		return CompressionGzip
+	case strings.HasSuffix(fileName, ".zst"):
+		return CompressionZstd
+	case strings.HasSuffix(fileName, ".sz"), strings.HasSuffix(fileName, ".snappy"):
+		return CompressionSnappy
+	default:
+		return CompressionNone
+	}
+}
+
+// CSVFilesFormatConfig configures ingestion of delimited text files.
+type CSVFilesFormatConfig struct {
+	Delimiter CSVDelimiter `yaml:"delimiter"`
+	Header    bool         `yaml:"header"`
+
+	// Compression indicates how the underlying CSV files are compressed. Defaults to
+	// CompressionAuto, which detects compression per-file from its extension.
+	Compression Compression `yaml:"compression"`
+}
+
+func (c *CSVFilesFormatConfig) Kind() string { return string(DataformatIDCSVFiles) }
+
+// Serialization is the data format used to read or write records for a SQL select
+// pushdown, e.g. via S3 Select.
+type Serialization = string
+
+const (
+	SerializationCSV     Serialization = "csv"
+	SerializationJSON    Serialization = "json"
+	SerializationParquet Serialization = "parquet"
+)
+
+// SQLSelectFormatConfig configures ingestion via a SQL expression pushed down to the
+// datasource itself (e.g. S3 Select), so that only the projected/filtered subset of
+// a file's records is read rather than the whole file.
+type SQLSelectFormatConfig struct {
+	// Expression is a SQL expression understood by the pushdown engine, e.g.
+	// `SELECT s.id, s.ts FROM S3Object s WHERE s.region='us'`.
+	Expression string `yaml:"expression"`
+
+	// InputSerialization is the format of the underlying files the expression is run
+	// against (CSV, JSON or Parquet).
+	InputSerialization Serialization `yaml:"inputSerialization"`
+	// OutputSerialization is the format the pushdown engine returns matched records
+	// in. S3 Select only supports returning CSV or JSON.
+	OutputSerialization Serialization `yaml:"outputSerialization"`
+
+	// Compression indicates how the underlying files are compressed.
+	Compression Compression `yaml:"compression"`
+}
+
+func (c *SQLSelectFormatConfig) Kind() string { return string(DataformatIDSQLSelect) }
@@ -0,0 +1,22 @@
+package ingest
+
+import "testing"
+
+func TestDetectCompression(t *testing.T) {
+	tests := []struct {
+		fileName string
+		want     Compression
+	}{
+		{"data.csv", CompressionNone},
+		{"data.csv.gz", CompressionGzip},
+		{"data.csv.zst", CompressionZstd},
+		{"data.csv.sz", CompressionSnappy},
+		{"data.csv.snappy", CompressionSnappy},
+		{"data", CompressionNone},
+	}
+	for _, tt := range tests {
+		if got := DetectCompression(tt.fileName); got != tt.want {
+			t.Errorf("DetectCompression(%q) = %q, want %q", tt.fileName, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,135 @@
+package ingest
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/container"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/service"
+)
+
+func init() {
+	RegisterLocationBackend(LocationBackendFactory{
+		ID:          DatasourceIDAzureBlob,
+		Name:        "Azure Blob Storage",
+		Description: "An Azure Blob Storage account, container and prefix.",
+		New:         func() LocationBackend { return &AzureBlobLocationConfig{} },
+		FromArgs:    newAzureBlobLocationConfigFromArgs,
+		CompleteArg: completeAzureBlobArg,
+	})
+}
+
+// AzureBlobLocationConfig configures an Azure Blob Storage account, container and
+// prefix as a datasource location. Either SASToken or ConnectionString must be set
+// to authenticate.
+type AzureBlobLocationConfig struct {
+	Account   string `yaml:"account"`
+	Container string `yaml:"container"`
+	Prefix    string `yaml:"prefix"`
+
+	// SASToken authenticates with a Shared Access Signature scoped to Account.
+	SASToken string `yaml:"sasToken,omitempty"`
+	// ConnectionString authenticates using an account connection string instead of a
+	// SAS token.
+	ConnectionString string `yaml:"connectionString,omitempty"`
+}
+
+func (c *AzureBlobLocationConfig) Kind() string { return string(DatasourceIDAzureBlob) }
+
+// newAzureBlobLocationConfigFromArgs builds an AzureBlobLocationConfig from
+// [account, container, prefix].
+func newAzureBlobLocationConfigFromArgs(args []string) (LocationBackend, error) {
+	if len(args) != 3 {
+		return nil, fmt.Errorf("azure_blob expects 3 arguments (account, container, prefix), got %d", len(args))
+	}
+	return &AzureBlobLocationConfig{Account: args[0], Container: args[1], Prefix: args[2]}, nil
+}
+
+func (c *AzureBlobLocationConfig) newContainerClient() (*container.Client, error) {
+	if c.ConnectionString != "" {
+		return container.NewClientFromConnectionString(c.ConnectionString, c.Container, nil)
+	}
+	serviceURL := fmt.Sprintf("https://%s.blob.core.windows.net/%s?%s", c.Account, c.Container, c.SASToken)
+	return container.NewClientWithNoCredential(serviceURL, nil)
+}
+
+func (c *AzureBlobLocationConfig) ListObjects() ([]ObjectRef, error) {
+	ctx := context.Background()
+	client, err := c.newContainerClient()
+	if err != nil {
+		return nil, fmt.Errorf("creating Azure Blob client: %w", err)
+	}
+
+	var refs []ObjectRef
+	pager := client.NewListBlobsFlatPager(&container.ListBlobsFlatOptions{Prefix: &c.Prefix})
+	for pager.More() {
+		page, err := pager.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("listing %s/%s/%s: %w", c.Account, c.Container, c.Prefix, err)
+		}
+		for _, blob := range page.Segment.BlobItems {
+			var size int64
+			if blob.Properties != nil && blob.Properties.ContentLength != nil {
+				size = *blob.Properties.ContentLength
+			}
+			refs = append(refs, ObjectRef{Key: *blob.Name, Size: size})
+		}
+	}
+	return refs, nil
+}
+
+func (c *AzureBlobLocationConfig) OpenObject(key string) (io.ReadCloser, error) {
+	ctx := context.Background()
+	client, err := c.newContainerClient()
+	if err != nil {
+		return nil, fmt.Errorf("creating Azure Blob client: %w", err)
+	}
+	resp, err := client.NewBlobClient(key).DownloadStream(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("reading %s/%s/%s: %w", c.Account, c.Container, key, err)
+	}
+	return resp.Body, nil
+}
+
+// completeAzureBlobArg completes container names for argIndex 1 (the account,
+// argIndex 0, is freeform since listing storage accounts requires subscription-level
+// credentials this CLI doesn't have). It authenticates with
+// AZURE_STORAGE_CONNECTION_STRING if set, falling back to an unauthenticated request
+// against the account named in priorArgs, and returns nil (no completions) rather than
+// an error on any failure, since shell completion must never fail the user's command
+// line.
+func completeAzureBlobArg(argIndex int, priorArgs []string, toComplete string) []string {
+	if argIndex != 1 || len(priorArgs) < 1 {
+		return nil
+	}
+	account := priorArgs[0]
+
+	var client *service.Client
+	var err error
+	if connectionString := os.Getenv("AZURE_STORAGE_CONNECTION_STRING"); connectionString != "" {
+		client, err = service.NewClientFromConnectionString(connectionString, nil)
+	} else {
+		client, err = service.NewClientWithNoCredential(fmt.Sprintf("https://%s.blob.core.windows.net/", account), nil)
+	}
+	if err != nil {
+		return nil
+	}
+
+	ctx := context.Background()
+	var names []string
+	pager := client.NewListContainersPager(nil)
+	for pager.More() {
+		page, err := pager.NextPage(ctx)
+		if err != nil {
+			return nil
+		}
+		for _, c := range page.ContainerItems {
+			if c.Name != nil {
+				names = append(names, *c.Name)
+			}
+		}
+	}
+	return names
+}
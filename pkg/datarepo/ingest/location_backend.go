@@ -0,0 +1,78 @@
+package ingest
+
+import "io"
+
+// ObjectRef identifies a single object within a LocationBackend, as returned by
+// ListObjects and accepted by OpenObject.
+type ObjectRef struct {
+	Key  string
+	Size int64
+}
+
+// LocationBackend is implemented by every datasource location config backed by an
+// object store (AWS S3, GCS, Azure Blob, ...). It lets the sampler list and open
+// objects without needing to know which concrete object store it's talking to.
+type LocationBackend interface {
+	ManifestConfig
+	// ListObjects returns the objects under this location for the sampler to choose
+	// from.
+	ListObjects() ([]ObjectRef, error)
+	// OpenObject opens a streaming reader for a single object, by the Key returned
+	// from ListObjects.
+	OpenObject(key string) (io.ReadCloser, error)
+}
+
+// LocationBackendFactory describes a pluggable datasource location backend: its
+// identity, how to construct an empty config for it (for prompting or decoding a
+// manifest), and how to offer shell completion for its location arguments.
+type LocationBackendFactory struct {
+	ID          DatasourceID
+	Name        string
+	Description string
+
+	// New constructs a zero-value LocationBackend of this backend's concrete type.
+	New func() LocationBackend
+
+	// FromArgs builds a populated LocationBackend directly from positional location
+	// arguments (e.g. [bucket, prefix] for AWS S3), the same arguments completed by
+	// CompleteArg. This lets the CLI skip its interactive prompts when the caller
+	// already knows the values (e.g. shell-completed them).
+	FromArgs func(args []string) (LocationBackend, error)
+
+	// CompleteArg returns shell-completion suggestions for the argIndex'th location
+	// argument (e.g. bucket, then prefix) given the values already chosen for prior
+	// arguments. Backends without anything completable (e.g. a free-form prefix) can
+	// leave this nil.
+	CompleteArg func(argIndex int, priorArgs []string, toComplete string) []string
+}
+
+var (
+	locationBackends      = map[DatasourceID]LocationBackendFactory{}
+	locationBackendsOrder []DatasourceID
+)
+
+// RegisterLocationBackend makes a location backend available for interactive
+// selection, manifest decoding and shell completion, without requiring any central
+// switch statement to be edited to support it.
+func RegisterLocationBackend(factory LocationBackendFactory) {
+	if _, exists := locationBackends[factory.ID]; !exists {
+		locationBackendsOrder = append(locationBackendsOrder, factory.ID)
+	}
+	locationBackends[factory.ID] = factory
+}
+
+// LookupLocationBackend returns the factory registered for id, if any.
+func LookupLocationBackend(id DatasourceID) (LocationBackendFactory, bool) {
+	factory, ok := locationBackends[id]
+	return factory, ok
+}
+
+// LocationBackendFactories returns all registered location backend factories, in
+// registration order.
+func LocationBackendFactories() []LocationBackendFactory {
+	factories := make([]LocationBackendFactory, 0, len(locationBackendsOrder))
+	for _, id := range locationBackendsOrder {
+		factories = append(factories, locationBackends[id])
+	}
+	return factories
+}
@@ -0,0 +1,55 @@
+package ingest
+
+import "testing"
+
+func TestRegisterAndLookupLocationBackend(t *testing.T) {
+	const id DatasourceID = "test_backend"
+	factory := LocationBackendFactory{
+		ID:          id,
+		Name:        "Test Backend",
+		Description: "A backend registered only for this test.",
+		New:         func() LocationBackend { return nil },
+		FromArgs:    func(args []string) (LocationBackend, error) { return nil, nil },
+	}
+
+	if _, ok := LookupLocationBackend(id); ok {
+		t.Fatalf("LookupLocationBackend(%q) found a backend before it was registered", id)
+	}
+
+	RegisterLocationBackend(factory)
+	t.Cleanup(func() {
+		delete(locationBackends, id)
+		for i, registeredID := range locationBackendsOrder {
+			if registeredID == id {
+				locationBackendsOrder = append(locationBackendsOrder[:i], locationBackendsOrder[i+1:]...)
+				break
+			}
+		}
+	})
+
+	got, ok := LookupLocationBackend(id)
+	if !ok {
+		t.Fatalf("LookupLocationBackend(%q) = false after RegisterLocationBackend", id)
+	}
+	if got.Name != factory.Name {
+		t.Errorf("LookupLocationBackend(%q).Name = %q, want %q", id, got.Name, factory.Name)
+	}
+
+	found := false
+	for _, f := range LocationBackendFactories() {
+		if f.ID == id {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("LocationBackendFactories() does not include %q", id)
+	}
+
+	// Re-registering the same ID should replace the factory without duplicating its
+	// entry in registration order.
+	before := len(locationBackendsOrder)
+	RegisterLocationBackend(factory)
+	if len(locationBackendsOrder) != before {
+		t.Errorf("re-registering %q changed locationBackendsOrder length: %d -> %d", id, before, len(locationBackendsOrder))
+	}
+}
@@ -0,0 +1,147 @@
+package ingest
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"cloud.google.com/go/storage"
+	"golang.org/x/oauth2/google"
+	"google.golang.org/api/iterator"
+	"google.golang.org/api/option"
+)
+
+func init() {
+	RegisterLocationBackend(LocationBackendFactory{
+		ID:          DatasourceIDGCS,
+		Name:        "Google Cloud Storage",
+		Description: "A GCS bucket and prefix (gs://bucket/prefix), indicating a collection of GCS objects.",
+		New:         func() LocationBackend { return &GCSLocationConfig{} },
+		FromArgs:    newGCSLocationConfigFromArgs,
+		CompleteArg: completeGCSArg,
+	})
+}
+
+// GCSLocationConfig configures a Google Cloud Storage bucket and prefix as a
+// datasource location, e.g. gs://bucket/prefix. By default it authenticates via
+// Application Default Credentials; CredentialsFile overrides this with a service
+// account key file.
+type GCSLocationConfig struct {
+	Bucket string `yaml:"bucket"`
+	Prefix string `yaml:"prefix"`
+
+	// CredentialsFile is a path to a service account JSON key file. Leave empty to
+	// use Application Default Credentials.
+	CredentialsFile string `yaml:"credentialsFile,omitempty"`
+}
+
+func (c *GCSLocationConfig) Kind() string { return string(DatasourceIDGCS) }
+
+// newGCSLocationConfigFromArgs builds a GCSLocationConfig from [bucket, prefix].
+func newGCSLocationConfigFromArgs(args []string) (LocationBackend, error) {
+	if len(args) != 2 {
+		return nil, fmt.Errorf("gcs expects 2 arguments (bucket, prefix), got %d", len(args))
+	}
+	return &GCSLocationConfig{Bucket: args[0], Prefix: args[1]}, nil
+}
+
+func (c *GCSLocationConfig) newClient(ctx context.Context) (*storage.Client, error) {
+	var opts []option.ClientOption
+	if c.CredentialsFile != "" {
+		opts = append(opts, option.WithCredentialsFile(c.CredentialsFile))
+	}
+	client, err := storage.NewClient(ctx, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("creating GCS client: %w", err)
+	}
+	return client, nil
+}
+
+func (c *GCSLocationConfig) ListObjects() ([]ObjectRef, error) {
+	ctx := context.Background()
+	client, err := c.newClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer client.Close()
+
+	var refs []ObjectRef
+	it := client.Bucket(c.Bucket).Objects(ctx, &storage.Query{Prefix: c.Prefix})
+	for {
+		attrs, err := it.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("listing gs://%s/%s: %w", c.Bucket, c.Prefix, err)
+		}
+		refs = append(refs, ObjectRef{Key: attrs.Name, Size: attrs.Size})
+	}
+	return refs, nil
+}
+
+func (c *GCSLocationConfig) OpenObject(key string) (io.ReadCloser, error) {
+	ctx := context.Background()
+	client, err := c.newClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	reader, err := client.Bucket(c.Bucket).Object(key).NewReader(ctx)
+	if err != nil {
+		client.Close()
+		return nil, fmt.Errorf("reading gs://%s/%s: %w", c.Bucket, key, err)
+	}
+	return &gcsObjectReader{reader: reader, client: client}, nil
+}
+
+// gcsObjectReader closes both the object reader and the client that created it, so
+// callers only need to Close() once.
+type gcsObjectReader struct {
+	reader *storage.Reader
+	client *storage.Client
+}
+
+func (r *gcsObjectReader) Read(p []byte) (int, error) { return r.reader.Read(p) }
+
+func (r *gcsObjectReader) Close() error {
+	readErr := r.reader.Close()
+	if clientErr := r.client.Close(); clientErr != nil {
+		return clientErr
+	}
+	return readErr
+}
+
+// completeGCSArg completes bucket names for argIndex 0 by listing buckets in the
+// Application Default Credentials' project; the prefix (argIndex 1) is free-form and
+// left to the user. It returns nil (no completions) rather than an error if ADC or a
+// project isn't available, since shell completion must never fail the user's command
+// line.
+func completeGCSArg(argIndex int, priorArgs []string, toComplete string) []string {
+	if argIndex != 0 {
+		return nil
+	}
+	ctx := context.Background()
+	creds, err := google.FindDefaultCredentials(ctx, storage.ScopeReadOnly)
+	if err != nil || creds.ProjectID == "" {
+		return nil
+	}
+	client, err := storage.NewClient(ctx)
+	if err != nil {
+		return nil
+	}
+	defer client.Close()
+
+	var names []string
+	it := client.Buckets(ctx, creds.ProjectID)
+	for {
+		attrs, err := it.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil
+		}
+		names = append(names, attrs.Name)
+	}
+	return names
+}
@@ -0,0 +1,206 @@
+package ingest
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+)
+
+func init() {
+	RegisterLocationBackend(LocationBackendFactory{
+		ID:          DatasourceIDAWSS3,
+		Name:        "AWS S3",
+		Description: "An AWS S3 Bucket and prefix, indicating a collection of AWS S3 objects.",
+		New:         func() LocationBackend { return &AWSS3LocationConfig{} },
+		FromArgs:    newAWSS3LocationConfigFromArgs,
+		CompleteArg: completeAWSS3Arg,
+	})
+}
+
+// AWSS3LocationConfig configures an AWS S3 bucket and prefix as a datasource location.
+type AWSS3LocationConfig struct {
+	Bucket string `yaml:"bucket"`
+	Prefix string `yaml:"prefix"`
+}
+
+func (c *AWSS3LocationConfig) Kind() string { return string(DatasourceIDAWSS3) }
+
+// newAWSS3LocationConfigFromArgs builds an AWSS3LocationConfig from [bucket, prefix].
+func newAWSS3LocationConfigFromArgs(args []string) (LocationBackend, error) {
+	if len(args) != 2 {
+		return nil, fmt.Errorf("aws_s3 expects 2 arguments (bucket, prefix), got %d", len(args))
+	}
+	return &AWSS3LocationConfig{Bucket: args[0], Prefix: args[1]}, nil
+}
+
+func (c *AWSS3LocationConfig) newClient(ctx context.Context) (*s3.Client, error) {
+	cfg, err := config.LoadDefaultConfig(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("loading AWS config: %w", err)
+	}
+	return s3.NewFromConfig(cfg), nil
+}
+
+func (c *AWSS3LocationConfig) ListObjects() ([]ObjectRef, error) {
+	ctx := context.Background()
+	client, err := c.newClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var refs []ObjectRef
+	paginator := s3.NewListObjectsV2Paginator(client, &s3.ListObjectsV2Input{
+		Bucket: &c.Bucket,
+		Prefix: &c.Prefix,
+	})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("listing s3://%s/%s: %w", c.Bucket, c.Prefix, err)
+		}
+		for _, obj := range page.Contents {
+			refs = append(refs, ObjectRef{Key: *obj.Key, Size: *obj.Size})
+		}
+	}
+	return refs, nil
+}
+
+func (c *AWSS3LocationConfig) OpenObject(key string) (io.ReadCloser, error) {
+	ctx := context.Background()
+	client, err := c.newClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	out, err := client.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: &c.Bucket,
+		Key:    &key,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("reading s3://%s/%s: %w", c.Bucket, key, err)
+	}
+	return out.Body, nil
+}
+
+// SelectObjectContent runs format.Expression against a single S3 object via S3
+// Select and returns a stream of the matched records, serialized as
+// format.OutputSerialization. Used by sample.SamplerFactory when building a Sampler
+// for a SQLSelectFormatConfig, which is only supported against AWS S3 today.
+func (c *AWSS3LocationConfig) SelectObjectContent(key string, format *SQLSelectFormatConfig) (io.ReadCloser, error) {
+	ctx := context.Background()
+	client, err := c.newClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	compression := format.Compression
+	if compression == CompressionAuto || compression == "" {
+		compression = DetectCompression(key)
+	}
+	inputSerialization, err := buildInputSerialization(format.InputSerialization, compression)
+	if err != nil {
+		return nil, err
+	}
+	outputSerialization, err := buildOutputSerialization(format.OutputSerialization)
+	if err != nil {
+		return nil, err
+	}
+
+	stream, err := client.SelectObjectContent(ctx, &s3.SelectObjectContentInput{
+		Bucket:              &c.Bucket,
+		Key:                 &key,
+		Expression:          &format.Expression,
+		ExpressionType:      types.ExpressionTypeSql,
+		InputSerialization:  inputSerialization,
+		OutputSerialization: outputSerialization,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("running S3 Select on s3://%s/%s: %w", c.Bucket, key, err)
+	}
+	return newSelectEventReader(stream.GetStream()), nil
+}
+
+// ValidSQLSelectCompression reports whether compression is one of the compression
+// types S3 Select can decompress before running its SQL expression against the
+// underlying file: gzip, or none/auto (zstd and snappy aren't supported).
+func ValidSQLSelectCompression(compression Compression) bool {
+	switch compression {
+	case CompressionGzip, CompressionNone, CompressionAuto, "":
+		return true
+	default:
+		return false
+	}
+}
+
+func buildInputSerialization(serialization Serialization, compression Compression) (*types.InputSerialization, error) {
+	if !ValidSQLSelectCompression(compression) {
+		return nil, fmt.Errorf("S3 Select does not support %s compression", compression)
+	}
+	s3Compression := types.CompressionTypeNone
+	if compression == CompressionGzip {
+		s3Compression = types.CompressionTypeGzip
+	}
+
+	switch serialization {
+	case SerializationCSV:
+		return &types.InputSerialization{
+			CompressionType: s3Compression,
+			CSV:             &types.CSVInput{FileHeaderInfo: types.FileHeaderInfoUse},
+		}, nil
+	case SerializationJSON:
+		return &types.InputSerialization{
+			CompressionType: s3Compression,
+			JSON:            &types.JSONInput{Type: types.JSONTypeLines},
+		}, nil
+	case SerializationParquet:
+		// Parquet compresses its columns internally; S3 Select accepts no whole-object
+		// compression for it.
+		return &types.InputSerialization{
+			CompressionType: types.CompressionTypeNone,
+			Parquet:         &types.ParquetInput{},
+		}, nil
+	default:
+		return nil, fmt.Errorf("unsupported input serialization: %s", serialization)
+	}
+}
+
+// buildOutputSerialization builds the S3 Select output serialization. S3 Select only
+// supports returning matched records as CSV or JSON.
+func buildOutputSerialization(serialization Serialization) (*types.OutputSerialization, error) {
+	switch serialization {
+	case SerializationCSV:
+		return &types.OutputSerialization{CSV: &types.CSVOutput{}}, nil
+	case SerializationJSON:
+		return &types.OutputSerialization{JSON: &types.JSONOutput{}}, nil
+	default:
+		return nil, fmt.Errorf("unsupported output serialization: %s", serialization)
+	}
+}
+
+// completeAWSS3Arg completes bucket names for argIndex 0; the prefix (argIndex 1) is
+// free-form and left to the user.
+func completeAWSS3Arg(argIndex int, priorArgs []string, toComplete string) []string {
+	if argIndex != 0 {
+		return nil
+	}
+	ctx := context.Background()
+	cfg, err := config.LoadDefaultConfig(ctx)
+	if err != nil {
+		return nil
+	}
+	client := s3.NewFromConfig(cfg)
+	out, err := client.ListBuckets(ctx, &s3.ListBucketsInput{})
+	if err != nil {
+		return nil
+	}
+	var names []string
+	for _, bucket := range out.Buckets {
+		if bucket.Name != nil {
+			names = append(names, *bucket.Name)
+		}
+	}
+	return names
+}
@@ -0,0 +1,92 @@
+package ingest
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+)
+
+func TestValidSQLSelectCompression(t *testing.T) {
+	tests := []struct {
+		compression Compression
+		want        bool
+	}{
+		{CompressionAuto, true},
+		{CompressionNone, true},
+		{CompressionGzip, true},
+		{"", true},
+		{CompressionZstd, false},
+		{CompressionSnappy, false},
+	}
+	for _, tt := range tests {
+		if got := ValidSQLSelectCompression(tt.compression); got != tt.want {
+			t.Errorf("ValidSQLSelectCompression(%q) = %v, want %v", tt.compression, got, tt.want)
+		}
+	}
+}
+
+func TestBuildInputSerialization(t *testing.T) {
+	tests := []struct {
+		name          string
+		serialization Serialization
+		compression   Compression
+		wantErr       string
+	}{
+		{name: "csv, no compression", serialization: SerializationCSV, compression: CompressionNone},
+		{name: "csv, gzip", serialization: SerializationCSV, compression: CompressionGzip},
+		{name: "json, auto compression", serialization: SerializationJSON, compression: CompressionAuto},
+		{name: "parquet", serialization: SerializationParquet, compression: CompressionNone},
+		{name: "unsupported compression", serialization: SerializationCSV, compression: CompressionZstd, wantErr: "S3 Select does not support zstd compression"},
+		{name: "unsupported serialization", serialization: "xml", compression: CompressionNone, wantErr: "unsupported input serialization"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := buildInputSerialization(tt.serialization, tt.compression)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("buildInputSerialization() error = %v, want containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("buildInputSerialization() unexpected error: %v", err)
+			}
+			if got.CompressionType == "" {
+				t.Errorf("buildInputSerialization() left CompressionType unset")
+			}
+			if tt.compression == CompressionGzip && got.CompressionType != types.CompressionTypeGzip {
+				t.Errorf("buildInputSerialization() CompressionType = %q, want %q", got.CompressionType, types.CompressionTypeGzip)
+			}
+		})
+	}
+}
+
+func TestBuildOutputSerialization(t *testing.T) {
+	tests := []struct {
+		name          string
+		serialization Serialization
+		wantErr       string
+	}{
+		{name: "csv", serialization: SerializationCSV},
+		{name: "json", serialization: SerializationJSON},
+		{name: "parquet unsupported", serialization: SerializationParquet, wantErr: "unsupported output serialization"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := buildOutputSerialization(tt.serialization)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("buildOutputSerialization() error = %v, want containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("buildOutputSerialization() unexpected error: %v", err)
+			}
+			if got == nil {
+				t.Fatalf("buildOutputSerialization() returned nil")
+			}
+		})
+	}
+}
@@ -0,0 +1,55 @@
+package ingest
+
+// FileEntry describes a single file backing an ingest, as recorded in the resolved
+// manifest that ingest workers consume. Plain (uncompressed) files are Splittable and
+// may be divided into multiple byte-range regions for parallel reading; compressed
+// files cannot be byte-range split, so they are always recorded as a single region.
+type FileEntry struct {
+	URI         string      `yaml:"uri"`
+	Compression Compression `yaml:"compression"`
+	Splittable  bool        `yaml:"splittable"`
+}
+
+// NewFileEntry resolves a FileEntry for uri, detecting its compression from the
+// format config when it is set to CompressionAuto.
+func NewFileEntry(uri string, formatCompression Compression) FileEntry {
+	compression := formatCompression
+	if compression == CompressionAuto || compression == "" {
+		compression = DetectCompression(uri)
+	}
+	return FileEntry{
+		URI:         uri,
+		Compression: compression,
+		Splittable:  compression == CompressionNone,
+	}
+}
+
+// FormatCompression returns the Compression configured on format, or CompressionNone
+// if format's concrete type doesn't carry one (e.g. individual binary files).
+func FormatCompression(format ManifestConfig) Compression {
+	switch fc := format.(type) {
+	case *CSVFilesFormatConfig:
+		return fc.Compression
+	case *SQLSelectFormatConfig:
+		return fc.Compression
+	default:
+		return CompressionNone
+	}
+}
+
+// ResolveFiles lists every object under location and resolves each into a FileEntry,
+// recording format's compression (and therefore splittability) so downstream ingest
+// workers know which files must be read as a single indivisible region rather than
+// being split into byte-range regions.
+func ResolveFiles(location LocationBackend, format ManifestConfig) ([]FileEntry, error) {
+	refs, err := location.ListObjects()
+	if err != nil {
+		return nil, err
+	}
+	compression := FormatCompression(format)
+	files := make([]FileEntry, len(refs))
+	for i, ref := range refs {
+		files[i] = NewFileEntry(ref.Key, compression)
+	}
+	return files, nil
+}
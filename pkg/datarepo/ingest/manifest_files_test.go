@@ -0,0 +1,72 @@
+package ingest
+
+import (
+	"io"
+	"reflect"
+	"testing"
+)
+
+func TestNewFileEntry(t *testing.T) {
+	tests := []struct {
+		name              string
+		uri               string
+		formatCompression Compression
+		want              FileEntry
+	}{
+		{
+			name:              "explicit compression",
+			uri:               "s3://bucket/key.bin",
+			formatCompression: CompressionGzip,
+			want:              FileEntry{URI: "s3://bucket/key.bin", Compression: CompressionGzip, Splittable: false},
+		},
+		{
+			name:              "auto detects from extension",
+			uri:               "s3://bucket/key.csv.zst",
+			formatCompression: CompressionAuto,
+			want:              FileEntry{URI: "s3://bucket/key.csv.zst", Compression: CompressionZstd, Splittable: false},
+		},
+		{
+			name:              "uncompressed files are splittable",
+			uri:               "s3://bucket/key.csv",
+			formatCompression: CompressionAuto,
+			want:              FileEntry{URI: "s3://bucket/key.csv", Compression: CompressionNone, Splittable: true},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewFileEntry(tt.uri, tt.formatCompression); got != tt.want {
+				t.Errorf("NewFileEntry(%q, %q) = %+v, want %+v", tt.uri, tt.formatCompression, got, tt.want)
+			}
+		})
+	}
+}
+
+// fakeLocationBackend is a minimal LocationBackend for testing ResolveFiles without a
+// real object store.
+type fakeLocationBackend struct {
+	refs []ObjectRef
+}
+
+func (f *fakeLocationBackend) Kind() string                             { return "fake" }
+func (f *fakeLocationBackend) ListObjects() ([]ObjectRef, error)        { return f.refs, nil }
+func (f *fakeLocationBackend) OpenObject(string) (io.ReadCloser, error) { return nil, nil }
+
+func TestResolveFiles(t *testing.T) {
+	location := &fakeLocationBackend{refs: []ObjectRef{
+		{Key: "a.csv", Size: 10},
+		{Key: "b.csv.gz", Size: 20},
+	}}
+	format := &CSVFilesFormatConfig{Compression: CompressionAuto}
+
+	got, err := ResolveFiles(location, format)
+	if err != nil {
+		t.Fatalf("ResolveFiles returned error: %v", err)
+	}
+	want := []FileEntry{
+		{URI: "a.csv", Compression: CompressionNone, Splittable: true},
+		{URI: "b.csv.gz", Compression: CompressionGzip, Splittable: false},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ResolveFiles() = %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,56 @@
+package sample
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+
+	"github.com/golang/snappy"
+	"github.com/klauspost/compress/zstd"
+
+	"github.com/Eventual-Inc/Daft/pkg/datarepo/ingest"
+)
+
+// decompress wraps r in a streaming decompressing reader for the given compression,
+// so callers can sample a compressed file without buffering it into memory first.
+// Closing the returned ReadCloser also closes r.
+func decompress(r io.ReadCloser, compression ingest.Compression) (io.ReadCloser, error) {
+	switch compression {
+	case ingest.CompressionNone, "":
+		return r, nil
+	case ingest.CompressionGzip:
+		gz, err := gzip.NewReader(r)
+		if err != nil {
+			r.Close()
+			return nil, fmt.Errorf("opening gzip stream: %w", err)
+		}
+		return &readCloser{Reader: gz, close: func() error {
+			gz.Close()
+			return r.Close()
+		}}, nil
+	case ingest.CompressionZstd:
+		zr, err := zstd.NewReader(r)
+		if err != nil {
+			r.Close()
+			return nil, fmt.Errorf("opening zstd stream: %w", err)
+		}
+		return &readCloser{Reader: zr.IOReadCloser(), close: func() error {
+			zr.Close()
+			return r.Close()
+		}}, nil
+	case ingest.CompressionSnappy:
+		return &readCloser{Reader: snappy.NewReader(r), close: r.Close}, nil
+	default:
+		r.Close()
+		return nil, fmt.Errorf("unsupported compression: %s", compression)
+	}
+}
+
+// readCloser adapts a decompressing io.Reader plus an explicit close func (which must
+// also close the underlying compressed stream) into an io.ReadCloser.
+type readCloser struct {
+	io.Reader
+	close func() error
+}
+
+func (rc *readCloser) Close() error { return rc.close() }
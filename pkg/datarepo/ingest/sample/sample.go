@@ -0,0 +1,206 @@
+// Package sample implements Samplers that read a bounded sample of a datasource's
+// records in order to infer a schema and build a preview, without materializing the
+// whole datasource.
+package sample
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+
+	"github.com/Eventual-Inc/Daft/pkg/datarepo/ingest"
+	"github.com/Eventual-Inc/Daft/pkg/datarepo/schema"
+)
+
+// maxSampleBytes bounds how much of a single file a Sampler reads in order to infer a
+// schema or build a preview, so that SampleSchema never has to materialize an entire
+// file (compressed files in particular can expand to many times their on-disk size).
+const maxSampleBytes = 4 << 20 // 4MiB
+
+const defaultSampleRows = 100
+
+// Sampler samples a small portion of a datasource's records in order to infer a
+// schema and build a human-readable preview.
+type Sampler interface {
+	// SampleSchema infers a schema.SchemaField describing the datasource's records.
+	SampleSchema() (*schema.SchemaField, error)
+	// Preview returns up to numRows sampled rows, rendered as strings per column.
+	Preview(numRows int) ([][]string, error)
+	// SniffStats returns per-column URI sniff statistics computed by the most recent
+	// SampleSchema call, or nil if URI sniffing wasn't performed (sampleURIs was 0, or
+	// this Sampler doesn't support URI columns).
+	SniffStats() []ColumnSniffStats
+}
+
+// SamplerFactory constructs the Sampler appropriate for the given format and location
+// configuration. sampleURIs bounds how many URI-looking values per CSV column are
+// followed and content-sniffed to refine the column's type; pass 0 to disable it.
+// sampleKey, if non-empty, is the object key to sample, letting callers that already
+// resolved a location's objects (e.g. to build an ingest manifest) reuse that listing
+// instead of paying for a second one; pass "" to have the Sampler list the location
+// itself and sample its first object.
+func SamplerFactory(formatConfig, locationConfig ingest.ManifestConfig, sampleURIs int, sampleKey string) (Sampler, error) {
+	switch fc := formatConfig.(type) {
+	case *ingest.CSVFilesFormatConfig:
+		loc, ok := locationConfig.(ingest.LocationBackend)
+		if !ok {
+			return nil, fmt.Errorf("csv files sampling is not yet supported for location %T", locationConfig)
+		}
+		return newCSVSampler(fc, loc, sampleURIs, sampleKey)
+	case *ingest.SQLSelectFormatConfig:
+		loc, ok := locationConfig.(*ingest.AWSS3LocationConfig)
+		if !ok {
+			return nil, fmt.Errorf("sql select sampling is not yet supported for location %T", locationConfig)
+		}
+		return newSQLSelectSampler(fc, loc, sampleKey)
+	default:
+		return nil, fmt.Errorf("sampling is not yet supported for data format %T", formatConfig)
+	}
+}
+
+// csvSampler samples a CSV datasource, transparently decompressing each sampled file
+// according to its resolved ingest.Compression so that SampleSchema and Preview never
+// need to see compressed bytes or materialize the whole (decompressed) file. It works
+// against any registered ingest.LocationBackend, not just AWS S3.
+type csvSampler struct {
+	format     *ingest.CSVFilesFormatConfig
+	location   ingest.LocationBackend
+	sampleURIs int
+
+	sampleKey  string
+	sniffStats []ColumnSniffStats
+}
+
+func newCSVSampler(format *ingest.CSVFilesFormatConfig, location ingest.LocationBackend, sampleURIs int, sampleKey string) (*csvSampler, error) {
+	if sampleKey == "" {
+		key, err := firstObjectKey(location)
+		if err != nil {
+			return nil, fmt.Errorf("finding a file to sample: %w", err)
+		}
+		sampleKey = key
+	}
+	return &csvSampler{format: format, location: location, sampleURIs: sampleURIs, sampleKey: sampleKey}, nil
+}
+
+// firstObjectKey returns the key of the first object under location, which samplers
+// use as a representative file to sample.
+func firstObjectKey(location ingest.LocationBackend) (string, error) {
+	refs, err := location.ListObjects()
+	if err != nil {
+		return "", err
+	}
+	if len(refs) == 0 {
+		return "", fmt.Errorf("no objects found for datasource location")
+	}
+	return refs[0].Key, nil
+}
+
+func (s *csvSampler) SampleSchema() (*schema.SchemaField, error) {
+	header, rows, err := s.sampleRows(defaultSampleRows)
+	if err != nil {
+		return nil, err
+	}
+	root := inferCSVSchema(header, rows)
+	if s.sampleURIs > 0 {
+		s.sniffStats = refineURIColumns(root, rows, s.sampleURIs)
+	}
+	return root, nil
+}
+
+func (s *csvSampler) SniffStats() []ColumnSniffStats { return s.sniffStats }
+
+func (s *csvSampler) Preview(numRows int) ([][]string, error) {
+	header, rows, err := s.sampleRows(numRows)
+	if err != nil {
+		return nil, err
+	}
+	if header != nil {
+		return append([][]string{header}, rows...), nil
+	}
+	return rows, nil
+}
+
+func (s *csvSampler) sampleRows(numRows int) (header []string, rows [][]string, err error) {
+	reader, err := s.openSampleReader()
+	if err != nil {
+		return nil, nil, err
+	}
+	defer reader.Close()
+	return readCSVRows(reader, s.format, numRows)
+}
+
+// openSampleReader opens the sampled object and, since compressed files cannot be
+// byte-range split, always streams it from the start through a decompressing reader
+// rather than seeking into it.
+func (s *csvSampler) openSampleReader() (io.ReadCloser, error) {
+	obj, err := s.location.OpenObject(s.sampleKey)
+	if err != nil {
+		return nil, err
+	}
+	compression := s.format.Compression
+	if compression == ingest.CompressionAuto || compression == "" {
+		compression = ingest.DetectCompression(s.sampleKey)
+	}
+	decompressed, err := decompress(obj, compression)
+	if err != nil {
+		return nil, err
+	}
+	return decompressed, nil
+}
+
+func readCSVRows(r io.Reader, format *ingest.CSVFilesFormatConfig, numRows int) (header []string, rows [][]string, err error) {
+	cr := csv.NewReader(io.LimitReader(r, maxSampleBytes))
+	cr.Comma = []rune(string(format.Delimiter))[0]
+	cr.FieldsPerRecord = -1
+
+	if format.Header {
+		header, err = cr.Read()
+		if err != nil && err != io.EOF {
+			return nil, nil, fmt.Errorf("reading csv header: %w", err)
+		}
+	}
+
+	rows = make([][]string, 0, numRows)
+	for len(rows) < numRows {
+		record, readErr := cr.Read()
+		if readErr == io.EOF {
+			break
+		}
+		if readErr != nil {
+			return nil, nil, fmt.Errorf("reading csv row: %w", readErr)
+		}
+		rows = append(rows, record)
+	}
+	return header, rows, nil
+}
+
+// inferCSVSchema builds a schema.SchemaField with one child per CSV column, using the
+// header row for names when present and falling back to positional names otherwise.
+// Every sampled value is currently treated as a string; refining columns that look
+// like URIs into richer Daft types is the responsibility of the URI sniffing pass.
+func inferCSVSchema(header []string, rows [][]string) *schema.SchemaField {
+	root := &schema.SchemaField{}
+	numCols := len(header)
+	if numCols == 0 && len(rows) > 0 {
+		numCols = len(rows[0])
+	}
+	if numCols == 0 {
+		return root
+	}
+
+	names := make([]string, numCols)
+	for i := range names {
+		names[i] = fmt.Sprintf("column_%d", i)
+	}
+	for i, v := range header {
+		if v != "" {
+			names[i] = v
+		}
+	}
+
+	root.Children = make([]schema.SchemaField, numCols)
+	for i, name := range names {
+		root.Children[i] = schema.SchemaField{Name: name, Type: schema.DaftTypeString}
+	}
+	return root
+}
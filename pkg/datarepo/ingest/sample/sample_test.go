@@ -0,0 +1,59 @@
+package sample
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/Eventual-Inc/Daft/pkg/datarepo/schema"
+)
+
+func TestInferCSVSchema(t *testing.T) {
+	tests := []struct {
+		name   string
+		header []string
+		rows   [][]string
+		want   []schema.SchemaField
+	}{
+		{
+			name:   "uses header names when present",
+			header: []string{"id", "name"},
+			rows:   [][]string{{"1", "a"}},
+			want: []schema.SchemaField{
+				{Name: "id", Type: schema.DaftTypeString},
+				{Name: "name", Type: schema.DaftTypeString},
+			},
+		},
+		{
+			name:   "falls back to positional names without a header",
+			header: nil,
+			rows:   [][]string{{"1", "a"}},
+			want: []schema.SchemaField{
+				{Name: "column_0", Type: schema.DaftTypeString},
+				{Name: "column_1", Type: schema.DaftTypeString},
+			},
+		},
+		{
+			name:   "blank header cells fall back to positional names",
+			header: []string{"id", ""},
+			rows:   [][]string{{"1", "a"}},
+			want: []schema.SchemaField{
+				{Name: "id", Type: schema.DaftTypeString},
+				{Name: "column_1", Type: schema.DaftTypeString},
+			},
+		},
+		{
+			name:   "no header and no rows yields no columns",
+			header: nil,
+			rows:   nil,
+			want:   nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := inferCSVSchema(tt.header, tt.rows)
+			if !reflect.DeepEqual(got.Children, tt.want) {
+				t.Errorf("inferCSVSchema(%v, %v).Children = %+v, want %+v", tt.header, tt.rows, got.Children, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,120 @@
+package sample
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/Eventual-Inc/Daft/pkg/datarepo/ingest"
+	"github.com/Eventual-Inc/Daft/pkg/datarepo/schema"
+)
+
+// sqlSelectSampler samples a datasource by pushing format.Expression down to S3
+// Select, so that schema inference and preview only ever see the projected/filtered
+// records the user asked for rather than the raw underlying files.
+type sqlSelectSampler struct {
+	format   *ingest.SQLSelectFormatConfig
+	location *ingest.AWSS3LocationConfig
+
+	sampleKey string
+}
+
+func newSQLSelectSampler(format *ingest.SQLSelectFormatConfig, location *ingest.AWSS3LocationConfig, sampleKey string) (*sqlSelectSampler, error) {
+	if sampleKey == "" {
+		key, err := firstObjectKey(location)
+		if err != nil {
+			return nil, fmt.Errorf("finding a file to sample: %w", err)
+		}
+		sampleKey = key
+	}
+	return &sqlSelectSampler{format: format, location: location, sampleKey: sampleKey}, nil
+}
+
+func (s *sqlSelectSampler) SampleSchema() (*schema.SchemaField, error) {
+	header, rows, err := s.sampleRows(defaultSampleRows)
+	if err != nil {
+		return nil, err
+	}
+	return inferCSVSchema(header, rows), nil
+}
+
+// SniffStats always returns nil: URI sniffing refines CSV columns sampled directly
+// from a datasource's files, which doesn't apply to records already projected through
+// a SQL select pushdown.
+func (s *sqlSelectSampler) SniffStats() []ColumnSniffStats { return nil }
+
+func (s *sqlSelectSampler) Preview(numRows int) ([][]string, error) {
+	header, rows, err := s.sampleRows(numRows)
+	if err != nil {
+		return nil, err
+	}
+	if header != nil {
+		return append([][]string{header}, rows...), nil
+	}
+	return rows, nil
+}
+
+func (s *sqlSelectSampler) sampleRows(numRows int) (header []string, rows [][]string, err error) {
+	records, err := s.location.SelectObjectContent(s.sampleKey, s.format)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer records.Close()
+
+	switch s.format.OutputSerialization {
+	case ingest.SerializationJSON:
+		return readJSONRows(records, numRows)
+	default:
+		return readCSVRows(records, &ingest.CSVFilesFormatConfig{Delimiter: ingest.CSVDelimiterCommas}, numRows)
+	}
+}
+
+// readJSONRows reads up to numRows newline-delimited JSON records (S3 Select's JSON
+// output format), treating each record's top-level keys as columns. The header
+// returned is the union of keys seen across sampled records, in first-seen order.
+func readJSONRows(r io.Reader, numRows int) (header []string, rows [][]string, err error) {
+	dec := json.NewDecoder(io.LimitReader(r, maxSampleBytes))
+	seen := map[string]int{}
+
+	var records []map[string]interface{}
+	for len(records) < numRows {
+		var raw json.RawMessage
+		decodeErr := dec.Decode(&raw)
+		if decodeErr == io.EOF {
+			break
+		}
+		if decodeErr != nil {
+			return nil, nil, fmt.Errorf("reading s3 select json record: %w", decodeErr)
+		}
+		var record map[string]interface{}
+		if err := json.Unmarshal(raw, &record); err != nil {
+			return nil, nil, fmt.Errorf("reading s3 select json record: %w", err)
+		}
+		// Keys are read from raw's tokens, not by ranging over record (whose order is
+		// random); raw already decoded as an object, so these reads cannot fail.
+		keyDec := json.NewDecoder(bytes.NewReader(raw))
+		keyDec.Token() // the record's opening '{'
+		for keyDec.More() {
+			tok, _ := keyDec.Token()
+			k := tok.(string)
+			if _, ok := seen[k]; !ok {
+				seen[k] = len(header)
+				header = append(header, k)
+			}
+			var value json.RawMessage
+			keyDec.Decode(&value)
+		}
+		records = append(records, record)
+	}
+
+	rows = make([][]string, len(records))
+	for i, record := range records {
+		row := make([]string, len(header))
+		for k, v := range record {
+			row[seen[k]] = fmt.Sprintf("%v", v)
+		}
+		rows[i] = row
+	}
+	return header, rows, nil
+}
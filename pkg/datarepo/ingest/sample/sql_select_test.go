@@ -0,0 +1,65 @@
+package sample
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestReadJSONRows(t *testing.T) {
+	tests := []struct {
+		name       string
+		input      string
+		numRows    int
+		wantHeader []string
+		wantRows   [][]string
+	}{
+		{
+			name:       "single record",
+			input:      `{"id": "1", "name": "alice"}` + "\n",
+			numRows:    100,
+			wantHeader: []string{"id", "name"},
+			wantRows:   [][]string{{"1", "alice"}},
+		},
+		{
+			name: "header is the union of keys in first-seen order",
+			input: `{"id": "1", "name": "alice"}
+{"id": "2", "age": "30"}
+`,
+			numRows:    100,
+			wantHeader: []string{"id", "name", "age"},
+			wantRows: [][]string{
+				{"1", "alice", ""},
+				{"2", "", "30"},
+			},
+		},
+		{
+			name:       "numRows bounds the records read",
+			input:      `{"id": "1"}` + "\n" + `{"id": "2"}` + "\n",
+			numRows:    1,
+			wantHeader: []string{"id"},
+			wantRows:   [][]string{{"1"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			header, rows, err := readJSONRows(strings.NewReader(tt.input), tt.numRows)
+			if err != nil {
+				t.Fatalf("readJSONRows() unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(header, tt.wantHeader) {
+				t.Errorf("readJSONRows() header = %v, want %v", header, tt.wantHeader)
+			}
+			if !reflect.DeepEqual(rows, tt.wantRows) {
+				t.Errorf("readJSONRows() rows = %v, want %v", rows, tt.wantRows)
+			}
+		})
+	}
+}
+
+func TestReadJSONRowsInvalidJSON(t *testing.T) {
+	_, _, err := readJSONRows(strings.NewReader(`not json`), 10)
+	if err == nil {
+		t.Fatal("readJSONRows() expected an error for malformed JSON, got nil")
+	}
+}
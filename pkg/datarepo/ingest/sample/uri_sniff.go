@@ -0,0 +1,233 @@
+package sample
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+
+	"github.com/Eventual-Inc/Daft/pkg/datarepo/schema"
+)
+
+// sniffBytes is how much of a referenced object is fetched in order to sniff its
+// content type from magic bytes; SampleSchema never needs the whole object.
+const sniffBytes = 512
+
+// ColumnSniffStats summarizes what a column's URI sniffing pass found: how many
+// sampled values looked like a URI, how many were successfully sniffed, and the
+// content type that was common enough to refine the column's Daft type.
+type ColumnSniffStats struct {
+	Column       string
+	URICount     int
+	SniffedCount int
+	ContentType  string
+}
+
+// looksLikeURI reports whether v looks like one of the URI types described in
+// SchemaEditorTutorialBlurb, and if so which Daft URI type it matches.
+func looksLikeURI(v string) (schema.DaftType, bool) {
+	switch {
+	case strings.HasPrefix(v, "s3://"):
+		return schema.DaftTypeURIS3, true
+	case strings.HasPrefix(v, "http://"), strings.HasPrefix(v, "https://"):
+		return schema.DaftTypeURIHTTP, true
+	default:
+		return "", false
+	}
+}
+
+// minURIMatchRatio is the minimum fraction of a column's non-empty sampled values that
+// must look like a URI before the column is refined to a URI type. This guards against
+// a single incidentally URL-shaped value (e.g. a stray link in an otherwise free-text
+// column) retyping the whole column.
+const minURIMatchRatio = 0.8
+
+// refineURIColumns inspects each of root's columns for values that look like URIs and,
+// for up to sampleURIs of them, fetches and content-sniffs the referenced object. A
+// column is only refined once at least minURIMatchRatio of its non-empty sampled values
+// look like a URI (of any scheme), and is then refined to the majority URI type; a
+// column whose sniffed values further agree on a single content type is
+// refined to a type like "uri/s3<image/jpeg>", while disagreement leaves it at the
+// plain URI type. It returns the per-column stats so the caller can show users what
+// was refined and why.
+func refineURIColumns(root *schema.SchemaField, rows [][]string, sampleURIs int) []ColumnSniffStats {
+	var stats []ColumnSniffStats
+	for col := range root.Children {
+		field := &root.Children[col]
+		uriType, contentTypes, uriCount, nonEmptyCount := sniffColumn(rows, col, sampleURIs)
+		if uriCount == 0 || float64(uriCount) < minURIMatchRatio*float64(nonEmptyCount) {
+			continue
+		}
+
+		stat := ColumnSniffStats{Column: field.Name, URICount: uriCount, SniffedCount: len(contentTypes)}
+		field.Type = uriType
+		if contentType, ok := soleContentType(contentTypes); ok {
+			field.Type = schema.DaftType(fmt.Sprintf("%s<%s>", uriType, contentType))
+			stat.ContentType = contentType
+		}
+		stats = append(stats, stat)
+	}
+	return stats
+}
+
+// sniffColumn scans rows for values in col that look like a URI, sniffing the content
+// type of up to sampleURIs of them. It returns the column's majority URI type (the one
+// matched by the most values, so a column mixing e.g. s3:// and http:// values isn't
+// mislabeled with whichever scheme happened to appear last), the content types
+// sniffed from values matching that majority type, the total number of values that
+// looked like a URI of any type (which may exceed len(contentTypes) if some values
+// couldn't be fetched or sampleURIs was reached), and the total number of non-empty
+// values seen, for computing what fraction of the column matched.
+func sniffColumn(rows [][]string, col, sampleURIs int) (uriType schema.DaftType, contentTypes []string, uriCount, nonEmptyCount int) {
+	uriTypeCounts := map[schema.DaftType]int{}
+	type sniffedValue struct {
+		uriType     schema.DaftType
+		contentType string
+	}
+	var sniffed []sniffedValue
+	var attempted int
+
+	for _, row := range rows {
+		if col >= len(row) || row[col] == "" {
+			continue
+		}
+		nonEmptyCount++
+		t, ok := looksLikeURI(row[col])
+		if !ok {
+			continue
+		}
+		uriTypeCounts[t]++
+		uriCount++
+		if attempted >= sampleURIs {
+			continue
+		}
+		attempted++
+		if contentType, err := sniffContentType(row[col]); err == nil {
+			sniffed = append(sniffed, sniffedValue{uriType: t, contentType: contentType})
+		}
+	}
+
+	uriType = majorityURIType(uriTypeCounts)
+	for _, s := range sniffed {
+		if s.uriType == uriType {
+			contentTypes = append(contentTypes, s.contentType)
+		}
+	}
+	return uriType, contentTypes, uriCount, nonEmptyCount
+}
+
+// majorityURIType returns the schema.DaftType with the highest count in counts, so a
+// column whose values match more than one URI scheme is labeled with whichever one
+// actually predominates rather than whichever was seen last.
+func majorityURIType(counts map[schema.DaftType]int) schema.DaftType {
+	var majority schema.DaftType
+	var majorityCount int
+	for t, count := range counts {
+		if count > majorityCount {
+			majority = t
+			majorityCount = count
+		}
+	}
+	return majority
+}
+
+// soleContentType returns the single content type in contentTypes if every entry
+// agrees, so that a column is only refined when sniffing gives a confident answer.
+func soleContentType(contentTypes []string) (string, bool) {
+	if len(contentTypes) == 0 {
+		return "", false
+	}
+	first := contentTypes[0]
+	for _, ct := range contentTypes[1:] {
+		if ct != first {
+			return "", false
+		}
+	}
+	return first, true
+}
+
+// sniffContentType fetches up to sniffBytes of the object referenced by uri and
+// returns its MIME type, detected from magic bytes.
+func sniffContentType(uri string) (string, error) {
+	r, err := openURIPrefix(uri)
+	if err != nil {
+		return "", err
+	}
+	defer r.Close()
+	buf, err := io.ReadAll(io.LimitReader(r, sniffBytes))
+	if err != nil {
+		return "", fmt.Errorf("sniffing %s: %w", uri, err)
+	}
+	return detectContentType(buf), nil
+}
+
+// detectContentType sniffs buf's content type from magic bytes. Parquet files are
+// recognized by their "PAR1" magic number, which net/http's sniffer doesn't know
+// about; everything else (images, audio, pdf, plain text, ...) defers to it.
+func detectContentType(buf []byte) string {
+	if bytes.HasPrefix(buf, []byte("PAR1")) {
+		return "application/x-parquet"
+	}
+	return http.DetectContentType(buf)
+}
+
+// openURIPrefix opens a streaming reader positioned at the start of the object
+// referenced by uri, requesting only enough of it to sniff its content type.
+func openURIPrefix(uri string) (io.ReadCloser, error) {
+	switch {
+	case strings.HasPrefix(uri, "s3://"):
+		return openS3URIPrefix(uri)
+	case strings.HasPrefix(uri, "http://"), strings.HasPrefix(uri, "https://"):
+		return openHTTPURIPrefix(uri)
+	default:
+		return nil, fmt.Errorf("unsupported uri scheme: %s", uri)
+	}
+}
+
+func openS3URIPrefix(uri string) (io.ReadCloser, error) {
+	parsed, err := url.Parse(uri)
+	if err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", uri, err)
+	}
+	bucket := parsed.Host
+	key := strings.TrimPrefix(parsed.Path, "/")
+
+	ctx := context.Background()
+	cfg, err := config.LoadDefaultConfig(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("loading AWS config: %w", err)
+	}
+	rang := fmt.Sprintf("bytes=0-%d", sniffBytes-1)
+	out, err := s3.NewFromConfig(cfg).GetObject(ctx, &s3.GetObjectInput{
+		Bucket: &bucket,
+		Key:    &key,
+		Range:  &rang,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %w", uri, err)
+	}
+	return out.Body, nil
+}
+
+func openHTTPURIPrefix(uri string) (io.ReadCloser, error) {
+	req, err := http.NewRequest(http.MethodGet, uri, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Range", fmt.Sprintf("bytes=0-%d", sniffBytes-1))
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("fetching %s: %w", uri, err)
+	}
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusPartialContent {
+		resp.Body.Close()
+		return nil, fmt.Errorf("fetching %s: unexpected status %s", uri, resp.Status)
+	}
+	return resp.Body, nil
+}
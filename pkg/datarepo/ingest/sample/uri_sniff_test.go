@@ -0,0 +1,138 @@
+package sample
+
+import (
+	"testing"
+
+	"github.com/Eventual-Inc/Daft/pkg/datarepo/schema"
+)
+
+func TestLooksLikeURI(t *testing.T) {
+	tests := []struct {
+		value  string
+		want   schema.DaftType
+		wantOK bool
+	}{
+		{"s3://bucket/key.jpg", schema.DaftTypeURIS3, true},
+		{"http://example.com/a.png", schema.DaftTypeURIHTTP, true},
+		{"https://example.com/a.png", schema.DaftTypeURIHTTP, true},
+		{"not a uri", "", false},
+		{"", "", false},
+		{"ftp://example.com/a", "", false},
+	}
+	for _, tt := range tests {
+		got, ok := looksLikeURI(tt.value)
+		if ok != tt.wantOK || got != tt.want {
+			t.Errorf("looksLikeURI(%q) = (%q, %v), want (%q, %v)", tt.value, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
+
+func TestSoleContentType(t *testing.T) {
+	tests := []struct {
+		name         string
+		contentTypes []string
+		want         string
+		wantOK       bool
+	}{
+		{"empty", nil, "", false},
+		{"single", []string{"image/jpeg"}, "image/jpeg", true},
+		{"all agree", []string{"image/jpeg", "image/jpeg", "image/jpeg"}, "image/jpeg", true},
+		{"disagreement", []string{"image/jpeg", "image/png"}, "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := soleContentType(tt.contentTypes)
+			if ok != tt.wantOK || got != tt.want {
+				t.Errorf("soleContentType(%v) = (%q, %v), want (%q, %v)", tt.contentTypes, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestDetectContentType(t *testing.T) {
+	tests := []struct {
+		name string
+		buf  []byte
+		want string
+	}{
+		{"parquet magic bytes", []byte("PAR1somecolumndata"), "application/x-parquet"},
+		{"png magic bytes", []byte{0x89, 'P', 'N', 'G', 0x0d, 0x0a, 0x1a, 0x0a}, "image/png"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := detectContentType(tt.buf); got != tt.want {
+				t.Errorf("detectContentType(%q) = %q, want %q", tt.buf, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSniffColumn(t *testing.T) {
+	// sniffContentType tries a real network fetch, which isn't available in this
+	// test, so these rows only exercise the URI-matching/counting logic: column 0 is
+	// all URIs, column 1 is plain strings, column 2 is mixed.
+	rows := [][]string{
+		{"s3://bucket/a.jpg", "alice", "s3://bucket/c.jpg"},
+		{"s3://bucket/b.jpg", "bob", "not-a-uri"},
+		{"s3://bucket/c.jpg", "carol", "not-a-uri"},
+	}
+
+	_, _, uriCount, nonEmptyCount := sniffColumn(rows, 0, 0)
+	if uriCount != 3 || nonEmptyCount != 3 {
+		t.Errorf("column 0: uriCount=%d nonEmptyCount=%d, want 3, 3", uriCount, nonEmptyCount)
+	}
+
+	_, _, uriCount, nonEmptyCount = sniffColumn(rows, 1, 0)
+	if uriCount != 0 || nonEmptyCount != 3 {
+		t.Errorf("column 1: uriCount=%d nonEmptyCount=%d, want 0, 3", uriCount, nonEmptyCount)
+	}
+
+	_, _, uriCount, nonEmptyCount = sniffColumn(rows, 2, 0)
+	if uriCount != 1 || nonEmptyCount != 3 {
+		t.Errorf("column 2: uriCount=%d nonEmptyCount=%d, want 1, 3", uriCount, nonEmptyCount)
+	}
+}
+
+func TestSniffColumnMixedSchemesPicksMajority(t *testing.T) {
+	// Two s3:// values and one http:// value: the column should be labeled with the
+	// majority scheme (s3), not whichever scheme happened to appear in the last row.
+	rows := [][]string{
+		{"s3://bucket/a.jpg"},
+		{"s3://bucket/b.jpg"},
+		{"http://example.com/c.jpg"},
+	}
+	uriType, _, uriCount, nonEmptyCount := sniffColumn(rows, 0, 0)
+	if uriType != schema.DaftTypeURIS3 {
+		t.Errorf("sniffColumn() uriType = %q, want majority type %q", uriType, schema.DaftTypeURIS3)
+	}
+	if uriCount != 3 || nonEmptyCount != 3 {
+		t.Errorf("sniffColumn() uriCount=%d nonEmptyCount=%d, want 3, 3", uriCount, nonEmptyCount)
+	}
+}
+
+func TestRefineURIColumnsRequiresMajorityMatch(t *testing.T) {
+	root := &schema.SchemaField{Children: []schema.SchemaField{
+		{Name: "all_uris", Type: schema.DaftTypeString},
+		{Name: "mostly_strings", Type: schema.DaftTypeString},
+	}}
+	rows := [][]string{
+		{"s3://bucket/a.jpg", "hello world"},
+		{"s3://bucket/b.jpg", "another sentence"},
+		{"s3://bucket/c.jpg", "s3://bucket/stray-link.jpg"},
+	}
+
+	// sampleURIs=0 skips content sniffing (no network in tests) while still exercising
+	// the majority-match gate in refineURIColumns.
+	stats := refineURIColumns(root, rows, 0)
+
+	if root.Children[0].Type != schema.DaftTypeURIS3 {
+		t.Errorf("all_uris column Type = %q, want refined to %q", root.Children[0].Type, schema.DaftTypeURIS3)
+	}
+	if root.Children[1].Type != schema.DaftTypeString {
+		t.Errorf("mostly_strings column Type = %q, want left as %q (one stray URI shouldn't retype it)", root.Children[1].Type, schema.DaftTypeString)
+	}
+
+	if len(stats) != 1 || stats[0].Column != "all_uris" {
+		t.Errorf("refineURIColumns() stats = %+v, want a single entry for all_uris", stats)
+	}
+}
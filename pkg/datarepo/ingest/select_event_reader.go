@@ -0,0 +1,49 @@
+package ingest
+
+import (
+	"bytes"
+	"io"
+
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+)
+
+// selectEventStream is the subset of the S3 Select event stream reader that
+// selectEventReader needs.
+type selectEventStream interface {
+	Events() <-chan types.SelectObjectContentEventStream
+	Close() error
+	Err() error
+}
+
+// selectEventReader adapts an S3 Select event stream into an io.ReadCloser over the
+// concatenated bytes of its RecordsEvent payloads, so callers can read sampled
+// records the same way they would read any other streaming object body.
+type selectEventReader struct {
+	stream selectEventStream
+	buf    bytes.Buffer
+}
+
+func newSelectEventReader(stream selectEventStream) *selectEventReader {
+	return &selectEventReader{stream: stream}
+}
+
+func (r *selectEventReader) Read(p []byte) (int, error) {
+	for r.buf.Len() == 0 {
+		event, ok := <-r.stream.Events()
+		if !ok {
+			if err := r.stream.Err(); err != nil {
+				return 0, err
+			}
+			return 0, io.EOF
+		}
+		if records, ok := event.(*types.SelectObjectContentEventStreamMemberRecords); ok {
+			r.buf.Write(records.Value.Payload)
+		}
+		// End/Progress/Stats events carry no record bytes; keep looping for more.
+	}
+	return r.buf.Read(p)
+}
+
+func (r *selectEventReader) Close() error {
+	return r.stream.Close()
+}
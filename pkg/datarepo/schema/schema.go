@@ -0,0 +1,23 @@
+// Package schema defines the Daft schema types used to describe the columns of a
+// Data Repo, as inferred by the ingest sampler and refined by users in the schema
+// editor.
+package schema
+
+// DaftType is a Daft column type, as shown to users in the schema editor. Simple
+// scalar types (e.g. "string") are represented directly; binary/URI columns carry a
+// refinement describing the content they point to (e.g. "uri/s3<image/jpeg>").
+type DaftType string
+
+const (
+	DaftTypeString  DaftType = "string"
+	DaftTypeURIS3   DaftType = "uri/s3"
+	DaftTypeURIHTTP DaftType = "uri/http"
+)
+
+// SchemaField is a single field in a Daft schema. The root SchemaField for a Data
+// Repo has no Name/Type of its own and holds one Children entry per column.
+type SchemaField struct {
+	Name     string        `yaml:"name,omitempty"`
+	Type     DaftType      `yaml:"type,omitempty"`
+	Children []SchemaField `yaml:"children,omitempty"`
+}